@@ -0,0 +1,322 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// annotationPrefix starts the comments above type declarations that list the
+// operations to generate for the type.
+const annotationPrefix = "//itergen:ops"
+
+// annotationCommand is the command in the header of the files generated from
+// annotations, which regenerates them when run in the package directory.
+const annotationCommand = commandName + " ."
+
+// annotation is an //itergen:ops comment above a type declaration.
+type annotation struct {
+	pos      token.Position
+	typeName string
+	ops      string
+}
+
+func (a annotation) String() string {
+	return fmt.Sprintf("%s:%d: %s %s", a.pos.Filename, a.pos.Line, annotationPrefix, a.ops)
+}
+
+// options returns the options to generate the iterable of the annotated type.
+// The operations are separated by commas, map and reduce are given as
+// "map=type" and "reduce=type", "name=Name" sets the name of the iterable,
+// "preset=name" and "all-ops" enable the operations of a preset or all the
+// supported ones, and "chan" generates a channel iterable instead of a slice
+// one. Registered operations are given by name, and the types of their
+// parameters as "name-param=type", like their options.
+func (a annotation) options() (Options, error) {
+	opts := Options{Type: a.typeName}
+	var params [][2]string
+	for _, op := range splitOps(a.ops) {
+		key, value := op, ""
+		if i := strings.Index(op, "="); i >= 0 {
+			key, value = strings.TrimSpace(op[:i]), strings.TrimSpace(op[i+1:])
+		}
+
+		switch {
+		case key == "map" && value != "":
+			opts.Map = append(opts.Map, value)
+		case key == "reduce" && value != "":
+			opts.Reduce = append(opts.Reduce, value)
+		case key == "name" && value != "":
+			opts.Name = value
+		case key == "preset" && value != "":
+			opts.Preset = value
+		case key == "all-ops" && value == "":
+			opts.AllOps = true
+		case key == "chan" && value == "":
+			opts.Type = "chan " + a.typeName
+		case isFlagOperation(key) && value == "":
+			*opts.flag(key) = true
+		case isRegisteredOperation(key) && value == "":
+			if opts.Plugins == nil {
+				opts.Plugins = make(map[string]map[string]string)
+			}
+			if opts.Plugins[key] == nil {
+				opts.Plugins[key] = make(map[string]string)
+			}
+		case isRegisteredParam(key) && value != "":
+			params = append(params, [2]string{key, value})
+		default:
+			return Options{}, fmt.Errorf("invalid operation %q, expecting one of %s", op, validAnnotationOps())
+		}
+	}
+
+	for _, p := range params {
+		name, param := splitRegisteredParam(p[0])
+		if opts.Plugins[name] == nil {
+			return Options{}, fmt.Errorf("%s can only be given along with %s", p[0], name)
+		}
+		opts.Plugins[name][param] = p[1]
+	}
+
+	return opts, nil
+}
+
+func isFlagOperation(name string) bool {
+	for _, op := range operations {
+		if op.Name == name && op.flag != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isRegisteredOperation(name string) bool {
+	_, ok := registeredOperation(name)
+	return ok
+}
+
+func isRegisteredParam(key string) bool {
+	name, _ := splitRegisteredParam(key)
+	return name != ""
+}
+
+// splitRegisteredParam returns the registered operation and the parameter
+// of the given "name-param" key, or empty strings if it is not one.
+func splitRegisteredParam(key string) (string, string) {
+	for _, op := range operations {
+		if op.IsBuiltin() {
+			continue
+		}
+
+		for _, param := range op.Params {
+			if key == op.Name+"-"+param {
+				return op.Name, param
+			}
+		}
+	}
+	return "", ""
+}
+
+func validAnnotationOps() string {
+	var ops []string
+	for _, op := range operations {
+		switch {
+		case op.flag != nil:
+			ops = append(ops, op.Name)
+		case !op.IsBuiltin():
+			ops = append(ops, op.Name)
+			for _, param := range op.Params {
+				ops = append(ops, op.Name+"-"+param+"=type")
+			}
+		}
+	}
+	sort.Strings(ops)
+
+	fixed := []string{"chan", "name=Name", "map=type", "reduce=type", "preset=name", "all-ops"}
+	return strings.Join(append(fixed, ops...), ", ")
+}
+
+// parseAnnotation returns the operations of the given comment if it is an
+// annotation.
+func parseAnnotation(comment string) (string, bool) {
+	if !strings.HasPrefix(comment, annotationPrefix) {
+		return "", false
+	}
+
+	ops := strings.TrimPrefix(comment, annotationPrefix)
+	if ops != "" && ops[0] != ' ' && ops[0] != '\t' {
+		return "", false
+	}
+
+	return strings.TrimSpace(ops), true
+}
+
+// splitOps splits the given operations at the commas that are not inside
+// brackets, braces or parenthesis, which may be part of map and reduce
+// types.
+func splitOps(ops string) []string {
+	var (
+		result []string
+		depth  int
+		start  int
+	)
+
+	for i, r := range ops {
+		switch r {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				result = append(result, strings.TrimSpace(ops[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	if last := strings.TrimSpace(ops[start:]); last != "" || len(result) > 0 {
+		result = append(result, last)
+	}
+
+	return result
+}
+
+// findAnnotations returns the name of the package in dir and the annotations
+// of the types declared in it.
+func findAnnotations(dir string) (string, []annotation, error) {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		if _, ok := err.(*build.NoGoError); ok {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+
+	var (
+		annotations []annotation
+		fset        = token.NewFileSet()
+	)
+
+	for _, name := range pkg.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return "", nil, err
+		}
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				docs := []*ast.CommentGroup{spec.Doc}
+				if len(gen.Specs) == 1 {
+					docs = append(docs, gen.Doc)
+				}
+
+				for _, doc := range docs {
+					if doc == nil {
+						continue
+					}
+
+					for _, comment := range doc.List {
+						ops, ok := parseAnnotation(comment.Text)
+						if !ok {
+							continue
+						}
+
+						a := annotation{fset.Position(comment.Pos()), spec.Name.Name, ops}
+						if spec.TypeParams != nil {
+							return "", nil, fmt.Errorf("%s: generic type %s can not be annotated, generate its instances with -t instead", a, a.typeName)
+						}
+						annotations = append(annotations, a)
+					}
+				}
+			}
+		}
+	}
+
+	return pkg.Name, annotations, nil
+}
+
+// GenerateAnnotations generates the iterables of all the annotated types of
+// the package in dir, and removes the files generated from annotations that
+// no longer exist. If force is true, files not generated by go-itergen are
+// overwritten.
+func GenerateAnnotations(dir string, force bool) error {
+	_, err := generateAnnotations(dir, force, false, nil)
+	return err
+}
+
+// CheckAnnotations returns an error showing the differences with the files on
+// disk if generating the iterables of the annotated types of the package in
+// dir would change, create or remove any file. Nothing is written.
+func CheckAnnotations(dir string) error {
+	_, err := generateAnnotations(dir, false, true, nil)
+	return err
+}
+
+// generateAnnotations generates or checks the iterables of the annotated
+// types in dir, returning their files. If regenerate is not nil, only the
+// iterables it returns true for are generated, see generateSome.
+func generateAnnotations(dir string, force, check bool, regenerate func(*Generator) bool) ([]string, error) {
+	gens, names, err := annotationGenerators(dir, force, check)
+	if err != nil {
+		return nil, err
+	}
+
+	written, kept, err := generateSome(gens, names, regenerate)
+	if err != nil && (!check || written == nil) {
+		return nil, err
+	}
+
+	keep := append(append([]string(nil), written...), kept...)
+	return written, joinErrors(err, removeStale(dir, annotationCommand, keep, check))
+}
+
+// annotationGenerators returns the generators of the iterables of the
+// annotated types in dir, along with their names for errors.
+func annotationGenerators(dir string, force, check bool) ([]*Generator, []string, error) {
+	pkg, annotations, err := findAnnotations(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		errs  []string
+		gens  []*Generator
+		names []string
+	)
+
+	for _, a := range annotations {
+		opts, err := a.options()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", a, err))
+			continue
+		}
+
+		opts.Package = pkg
+		opts.Dir = dir
+		opts.Force = force
+		opts.Check = check
+
+		g := New(opts)
+		g.origin = annotationCommand
+		gens = append(gens, g)
+		names = append(names, a.String())
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, validationError(errs)
+	}
+
+	return gens, names, nil
+}
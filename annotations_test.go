@@ -0,0 +1,213 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type AnnotationsSuite struct {
+	dir string
+}
+
+var _ = Suite(&AnnotationsSuite{})
+
+func (s *AnnotationsSuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+}
+
+func (s *AnnotationsSuite) write(c *C, file, code string) {
+	c.Assert(ioutil.WriteFile(filepath.Join(s.dir, file), []byte(code), 0644), IsNil)
+}
+
+func (s *AnnotationsSuite) exists(file string) bool {
+	_, err := os.Stat(filepath.Join(s.dir, file))
+	return err == nil
+}
+
+func (s *AnnotationsSuite) TestSplitOps(c *C) {
+	tcs := []struct {
+		input  string
+		output []string
+	}{
+		{"", nil},
+		{"filter", []string{"filter"}},
+		{"filter, map=string ,reduce=int", []string{"filter", "map=string", "reduce=int"}},
+		{"map=func(a, b int) string,map=struct{ A, B int }", []string{"map=func(a, b int) string", "map=struct{ A, B int }"}},
+		{"map=pkg.Pair[string, int],some", []string{"map=pkg.Pair[string, int]", "some"}},
+	}
+
+	for _, tc := range tcs {
+		c.Assert(splitOps(tc.input), DeepEquals, tc.output)
+	}
+}
+
+func (s *AnnotationsSuite) TestParseAnnotation(c *C) {
+	tcs := []struct {
+		comment string
+		ops     string
+		ok      bool
+	}{
+		{"//itergen:ops filter,some", "filter,some", true},
+		{"//itergen:ops", "", true},
+		{"//itergen:opsfilter", "", false},
+		{"// itergen:ops filter", "", false},
+		{"//go:generate go-itergen -t int", "", false},
+	}
+
+	for _, tc := range tcs {
+		ops, ok := parseAnnotation(tc.comment)
+		c.Assert(ops, Equals, tc.ops)
+		c.Assert(ok, Equals, tc.ok)
+	}
+}
+
+func (s *AnnotationsSuite) TestAnnotationOptions(c *C) {
+	tcs := []struct {
+		ops  string
+		opts Options
+		err  string
+	}{
+		{
+			"filter,map=string,reduce=Total=int,map=int",
+			Options{Type: "Order", Filter: true, Map: []string{"string", "int"}, Reduce: []string{"Total=int"}},
+			"",
+		},
+		{
+			"chan,name=Orders,array,concat",
+			Options{Type: "chan Order", Name: "Orders", Array: true, Concat: true},
+			"",
+		},
+		{"filter,filtr", Options{}, `invalid operation "filtr", expecting one of chan, name=Name, map=type, reduce=type, preset=name, all-ops, all, .*`},
+		{"map", Options{}, `invalid operation "map", .*`},
+		{"chan=int", Options{}, `invalid operation "chan=int", .*`},
+	}
+
+	for _, tc := range tcs {
+		opts, err := annotation{typeName: "Order", ops: tc.ops}.options()
+		if tc.err != "" {
+			c.Assert(err, ErrorMatches, tc.err)
+			continue
+		}
+
+		c.Assert(err, IsNil)
+		c.Assert(opts, DeepEquals, tc.opts)
+	}
+}
+
+func (s *AnnotationsSuite) TestAnnotationRegisteredOperations(c *C) {
+	builtin := operations
+	defer func() { operations = builtin }()
+
+	c.Assert(RegisterOperation(Operation{Name: "window", Slice: true, Template: "x", Params: []string{"size"}}), IsNil)
+	c.Assert(RegisterOperation(Operation{Name: "shuffle", Slice: true, Template: "x"}), IsNil)
+
+	opts, err := annotation{typeName: "Order", ops: "filter,window-size=int,window,shuffle"}.options()
+	c.Assert(err, IsNil)
+	c.Assert(opts, DeepEquals, Options{
+		Type:    "Order",
+		Filter:  true,
+		Plugins: map[string]map[string]string{"window": {"size": "int"}, "shuffle": {}},
+	})
+
+	_, err = annotation{typeName: "Order", ops: "window-size=int"}.options()
+	c.Assert(err, ErrorMatches, "window-size can only be given along with window")
+
+	_, err = annotation{typeName: "Order", ops: "windw"}.options()
+	c.Assert(err, ErrorMatches, `invalid operation "windw", expecting one of .*, reverse, shuffle, some, splice, window, window-size=type`)
+}
+
+func (s *AnnotationsSuite) TestFindAnnotations(c *C) {
+	s.write(c, "foo.go", `package foo
+
+//itergen:ops filter,map=string
+type Order struct{}
+
+type (
+	// Event is an event.
+	//itergen:ops chan,array
+	//itergen:ops name=Events,find
+	Event struct{}
+
+	Other int
+)
+
+// Unannotated is not annotated.
+type Unannotated int
+`)
+
+	pkg, annotations, err := findAnnotations(s.dir)
+	c.Assert(err, IsNil)
+	c.Assert(pkg, Equals, "foo")
+
+	var found []string
+	for _, a := range annotations {
+		found = append(found, a.typeName+": "+a.ops)
+	}
+	c.Assert(found, DeepEquals, []string{
+		"Order: filter,map=string",
+		"Event: chan,array",
+		"Event: name=Events,find",
+	})
+	c.Assert(annotations[0].String(), Matches, `.*foo.go:3: //itergen:ops filter,map=string`)
+
+	s.write(c, "generic.go", `package foo
+
+//itergen:ops filter
+type Pair[K comparable, V any] struct{}
+`)
+	_, _, err = findAnnotations(s.dir)
+	c.Assert(err, ErrorMatches, `.*generic.go:3: //itergen:ops filter: generic type Pair can not be annotated, .*`)
+}
+
+func (s *AnnotationsSuite) TestGenerateAnnotations(c *C) {
+	s.write(c, "foo.go", `package foo
+
+//itergen:ops filter,map=string
+type Order struct{}
+
+//itergen:ops chan,filter
+type Event struct{}
+`)
+
+	c.Assert(CheckAnnotations(s.dir), ErrorMatches, `(?s).*order_iter.go is not up to date.*`)
+	c.Assert(GenerateAnnotations(s.dir, false), IsNil)
+	c.Assert(s.exists("order_iter.go"), Equals, true)
+	c.Assert(s.exists("eventchan_iter.go"), Equals, true)
+	c.Assert(CheckAnnotations(s.dir), IsNil)
+
+	generated, command, err := readHeader(filepath.Join(s.dir, "order_iter.go"))
+	c.Assert(err, IsNil)
+	c.Assert(generated, Equals, true)
+	c.Assert(command, Equals, "go-itergen .")
+
+	s.write(c, "foo.go", `package foo
+
+//itergen:ops filter,map=string
+type Order struct{}
+
+type Event struct{}
+`)
+	c.Assert(GenerateAnnotations(s.dir, false), IsNil)
+	c.Assert(s.exists("order_iter.go"), Equals, true)
+	c.Assert(s.exists("eventchan_iter.go"), Equals, false)
+
+	s.write(c, "foo.go", `package foo
+
+//itergen:ops chan,some
+type Order struct{}
+
+//itergen:ops filtr
+type Event struct{}
+`)
+	c.Assert(GenerateAnnotations(s.dir, false), ErrorMatches, `.*foo.go:6: //itergen:ops filtr: invalid operation "filtr", .*`)
+
+	s.write(c, "foo.go", `package foo
+
+//itergen:ops chan,some
+type Order struct{}
+`)
+	c.Assert(GenerateAnnotations(s.dir, false), ErrorMatches, `.*foo.go:3: //itergen:ops chan,some: --some is not supported by chan types, remove it or use a slice type`)
+}
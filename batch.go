@@ -0,0 +1,328 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"strings"
+)
+
+// singleFileName is the file all the iterables are written to in single file
+// mode when no output is given.
+const singleFileName = "itergen_iter.go"
+
+type generatedFile struct {
+	file  string
+	code  []byte
+	check bool
+}
+
+// generateAll generates the code of all the given generators, which are
+// identified by the given names in errors. Nothing is written unless the code
+// of all of them can be generated, and their files and identifiers do not
+// collide. Generators in check mode write nothing, and fail if their files
+// are not up to date. It returns the files with generated code.
+func generateAll(gens []*Generator, names []string) ([]string, error) {
+	written, _, err := generateSome(gens, names, nil)
+	return written, err
+}
+
+// generateSome is like generateAll, but if regenerate is not nil only the
+// generators it returns true for are generated. The files and identifiers of
+// the rest are still checked not to collide with the generated ones, and
+// their files, which are left as they are, are returned as kept.
+func generateSome(gens []*Generator, names []string, regenerate func(*Generator) bool) (written, kept []string, err error) {
+	var (
+		outputs  []generatedFile
+		errs     []string
+		files    = make(map[string]string)
+		idents   = make(map[string]string)
+		packages = make(map[string]string)
+	)
+
+	shareLoader(gens)
+
+	for i, g := range gens {
+		if regenerate != nil && !regenerate(g) {
+			// only the options are parsed, which is enough to know the
+			// file and the identifiers of the iterable
+			if err := g.parseTypes(); err != nil {
+				errs = append(errs, batchProblems(names[i], err)...)
+				continue
+			}
+
+			errs = append(errs, checkPackageIdentifiers(idents, g, names[i])...)
+			if file := g.outputFile(); file != stdout {
+				if prev, ok := files[file]; ok {
+					errs = append(errs, fmt.Sprintf("%s: file %s is also generated by %s", names[i], file, prev))
+					continue
+				}
+				files[file] = names[i]
+				kept = append(kept, file)
+			}
+			continue
+		}
+
+		code, file, err := g.GenerateSource()
+		if err != nil {
+			errs = append(errs, batchProblems(names[i], err)...)
+			continue
+		}
+
+		if file != stdout {
+			if prev, ok := files[file]; ok {
+				errs = append(errs, fmt.Sprintf("%s: file %s is also generated by %s", names[i], file, prev))
+				continue
+			}
+			files[file] = names[i]
+		}
+
+		if prev, ok := packages[g.dir()]; ok && prev != g.packageName() {
+			errs = append(errs, fmt.Sprintf("%s: package %s does not match package %s of the other iterables in %s", names[i], g.packageName(), prev, g.dir()))
+			continue
+		}
+		packages[g.dir()] = g.packageName()
+
+		errs = append(errs, checkPackageIdentifiers(idents, g, names[i])...)
+
+		if !g.Force && !g.Check && file != stdout {
+			if err := checkOverwrite(file); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %s", names[i], err))
+				continue
+			}
+		}
+
+		outputs = append(outputs, generatedFile{file, code, g.Check})
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, validationError(errs)
+	}
+
+	for _, out := range outputs {
+		if out.check {
+			if err := checkOutput(out.file, out.code); err != nil {
+				errs = append(errs, err.Error())
+			}
+		} else if err := writeOutput(out.file, out.code); err != nil {
+			return written, kept, err
+		}
+
+		if out.file != stdout {
+			written = append(written, out.file)
+		}
+	}
+
+	if len(errs) > 0 {
+		return written, kept, errors.New(strings.Join(errs, "\n"))
+	}
+
+	return written, kept, nil
+}
+
+// checkPackageIdentifiers returns an error for every package level
+// identifier of the generator, identified by name, that is already in
+// idents, which maps the identifiers generated in each directory to the name
+// of the generator of the iterable they belong to.
+func checkPackageIdentifiers(idents map[string]string, g *Generator, name string) []string {
+	var errs []string
+	for _, id := range g.identifiers() {
+		if id.recv != "" {
+			continue
+		}
+
+		key := g.dir() + "." + id.name
+		if prev, ok := idents[key]; ok {
+			errs = append(errs, fmt.Sprintf("%s: %s is also generated by %s", name, id, prev))
+			continue
+		}
+		idents[key] = name
+	}
+
+	return errs
+}
+
+// batchProblems returns the problems of the error of the generator
+// identified by name, prefixed with the name unless they already start with
+// it.
+func batchProblems(name string, err error) []string {
+	var problems []string
+	for _, p := range problemsOf(err) {
+		if !strings.HasPrefix(p, name+": ") {
+			p = fmt.Sprintf("%s: %s", name, p)
+		}
+		problems = append(problems, p)
+	}
+
+	return problems
+}
+
+// joinErrors returns an error with the messages of all the given errors that
+// are not nil, one per line, or nil if all of them are.
+func joinErrors(errs ...error) error {
+	var msgs []string
+	for _, err := range errs {
+		if err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(msgs, "\n"))
+}
+
+// GenerateTypes generates the iterables of all the given types with the
+// options of the generator, whose type is ignored. Each iterable is written
+// to its own file unless singleFile is true, in which case all of them are
+// written to Output or, if empty, to itergen_iter.go in Dir.
+func (g *Generator) GenerateTypes(types []string, singleFile bool) error {
+	if len(types) == 0 {
+		return errors.New("no type given")
+	}
+
+	if !singleFile && len(types) == 1 {
+		gen := *g
+		gen.RawType = types[0]
+		return gen.Generate()
+	}
+
+	return atGenerateLine(g.generateTypes(types, singleFile))
+}
+
+// generateTypes generates the iterables of the given types, which are at
+// least two unless singleFile is true, recording the command with all of
+// them in the header of every file.
+func (g *Generator) generateTypes(types []string, singleFile bool) error {
+	if !singleFile && g.Output != "" {
+		return errors.New("--output can not be used with several types unless --single-file is given")
+	}
+
+	var (
+		extra []string
+		gens  = make([]*Generator, len(types))
+		names = make([]string, len(types))
+	)
+
+	if singleFile {
+		extra = append(extra, "--single-file")
+	}
+
+	for i, t := range types {
+		gen := *g
+		gen.RawType = t
+		gen.origin = g.commandFor(types, extra...)
+		gens[i] = &gen
+		names[i] = fmt.Sprintf("-t %q", t)
+	}
+
+	if !singleFile {
+		_, err := generateAll(gens, names)
+		return err
+	}
+
+	file := g.Output
+	if file == "" {
+		file = filepath.Join(g.dir(), singleFileName)
+	}
+
+	for _, gen := range gens {
+		gen.Output = file
+	}
+
+	return generateSingleFile(gens, names, file)
+}
+
+// generateSingleFile generates the code of all the given generators, which
+// are identified by the given names in errors, into the given file. The
+// options to write it are taken from the first generator.
+func generateSingleFile(gens []*Generator, names []string, file string) error {
+	var (
+		errs    []string
+		idents  = make(map[string]string)
+		imports = make(map[string]importUse)
+	)
+
+	shareLoader(gens)
+
+	for i, g := range gens {
+		if err := g.prepare(); err != nil {
+			errs = append(errs, batchProblems(names[i], err)...)
+			continue
+		}
+
+		errs = append(errs, checkPackageIdentifiers(idents, g, names[i])...)
+		errs = append(errs, checkImports(imports, g, names[i])...)
+	}
+
+	first := gens[0]
+	if !first.Force && !first.Check && file != stdout {
+		if err := checkOverwrite(file); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return validationError(errs)
+	}
+
+	code, err := generateFileSource(gens)
+	if err != nil {
+		return err
+	}
+
+	if first.Check {
+		return checkOutput(file, code)
+	}
+
+	return writeOutput(file, code)
+}
+
+// generateFileSource returns the formatted code of a file containing the
+// iterables of all the given prepared generators, with a single package
+// clause and import block.
+func generateFileSource(gens []*Generator) ([]byte, error) {
+	for _, g := range gens {
+		g.fileTypes = nil
+		for _, other := range gens {
+			if other != g {
+				g.fileTypes = append(g.fileTypes, other.typeDefs()...)
+			}
+		}
+	}
+
+	// the bodies are generated first so the imports include the packages
+	// the templates request
+	var (
+		imports []Import
+		seen    = make(map[Import]bool)
+		body    = bytes.NewBuffer(nil)
+	)
+
+	for _, g := range gens {
+		g.requested = nil
+		if err := g.generateBody(body); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, g := range gens {
+		for _, imp := range g.imports() {
+			if !seen[imp] {
+				seen[imp] = true
+				imports = append(imports, imp)
+			}
+		}
+	}
+	sortImports(imports)
+
+	code, err := gens[0].generateFile(imports, body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(code)
+}
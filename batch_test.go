@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type BatchSuite struct {
+	dir string
+}
+
+var _ = Suite(&BatchSuite{})
+
+func (s *BatchSuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+}
+
+func (s *BatchSuite) read(c *C, file string) string {
+	code, err := ioutil.ReadFile(filepath.Join(s.dir, file))
+	c.Assert(err, IsNil)
+	return string(code)
+}
+
+func (s *BatchSuite) TestGenerateTypes(c *C) {
+	g := &Generator{Package: "foo", Filter: true, Dir: s.dir}
+	c.Assert(g.GenerateTypes([]string{"float64", "chan string"}, false), IsNil)
+
+	for _, file := range []string{"float64_iter.go", "stringchan_iter.go"} {
+		generated, command, err := readHeader(filepath.Join(s.dir, file))
+		c.Assert(err, IsNil)
+		c.Assert(generated, Equals, true)
+		c.Assert(command, Matches, `go-itergen --type=float64 --type='chan string' --pkg=foo --filter`)
+	}
+
+	g = &Generator{Package: "foo", Filter: true, Dir: s.dir}
+	err := g.GenerateTypes([]string{"int", "int"}, false)
+	c.Assert(err, ErrorMatches, `-t "int": file .*int_iter.go is also generated by -t "int"`)
+
+	g = &Generator{Package: "foo", Filter: true, Output: "-"}
+	err = g.GenerateTypes([]string{"int", "string"}, false)
+	c.Assert(err, ErrorMatches, "--output can not be used with several types unless --single-file is given")
+}
+
+func (s *BatchSuite) TestGenerateTypesSingleFile(c *C) {
+	g := &Generator{Package: "foo", Map: []string{"int"}, Dir: s.dir}
+	c.Assert(g.GenerateTypes([]string{"float64", "*os.File", "chan string"}, true), IsNil)
+
+	code := s.read(c, singleFileName)
+	c.Assert(strings.Count(code, "package foo"), Equals, 1)
+	c.Assert(strings.Count(code, "import ("), Equals, 1)
+	c.Assert(strings.Count(code, `"errors"`), Equals, 1)
+	c.Assert(code, Matches, `(?s).*"os".*type Float64Iter .*type OsFileIter .*type StringChanIter .*`)
+
+	generated, command, err := readHeader(filepath.Join(s.dir, singleFileName))
+	c.Assert(err, IsNil)
+	c.Assert(generated, Equals, true)
+	c.Assert(command, Matches, `go-itergen --type=float64 --type='\*os.File' --type='chan string' --pkg=foo --map=int --single-file`)
+}
+
+func (s *BatchSuite) TestGenerateTypesSingleFileErrors(c *C) {
+	g := &Generator{Package: "foo", Filter: true, Dir: s.dir}
+	err := g.GenerateTypes([]string{"float64", "nope.X", "float64"}, true)
+	c.Assert(err, ErrorMatches, `-t "nope.X": cannot find package nope, .*
+-t "float64": Float64Iter is also generated by -t "float64"
+-t "float64": NewFloat64Iter is also generated by -t "float64"`)
+
+	err = g.GenerateTypes([]string{"text/template:*template.Template", "html/template:template.JS"}, true)
+	c.Assert(err, ErrorMatches, `-t "html/template:template.JS" imports html/template as template, like text/template imported by -t "text/template:\*template.Template", give one of them an alias with "path as alias:"`)
+
+	file := filepath.Join(s.dir, singleFileName)
+	c.Assert(ioutil.WriteFile(file, []byte("package foo\n"), 0644), IsNil)
+	g = &Generator{Package: "foo", Filter: true, Dir: s.dir}
+	err = g.GenerateTypes([]string{"float64"}, true)
+	c.Assert(err, ErrorMatches, "refusing to overwrite .*")
+}
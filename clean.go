@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Clean removes all the files generated by go-itergen in the directory tree
+// rooted at root and returns them. vendor and testdata directories are
+// skipped, as well as the ones starting with "." or "_".
+func Clean(root string) ([]string, error) {
+	var removed []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != root && ignoredDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		generated, err := isGenerated(path)
+		if err != nil || !generated {
+			return err
+		}
+
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+
+		removed = append(removed, path)
+		return nil
+	})
+
+	return removed, err
+}
@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type CleanSuite struct{}
+
+var _ = Suite(&CleanSuite{})
+
+func (s *CleanSuite) TestClean(c *C) {
+	dir := c.MkDir()
+	files := map[string]string{
+		"foo.go":                  "package foo\n",
+		"float64_iter.go":         generatedHeader + "\n\npackage foo\n",
+		"sub/int_iter.go":         generatedHeader + "\n\npackage sub\n",
+		"sub/README.md":           generatedHeader + "\n",
+		"testdata/int_iter.go":    generatedHeader + "\n\npackage foo\n",
+		"vendor/x/int_iter.go":    generatedHeader + "\n\npackage x\n",
+		"sub/other_generated.go":  "// Code generated by stringer. DO NOT EDIT.\n\npackage sub\n",
+		".hidden/float64_iter.go": generatedHeader + "\n\npackage foo\n",
+	}
+
+	for file, content := range files {
+		file = filepath.Join(dir, file)
+		c.Assert(os.MkdirAll(filepath.Dir(file), 0755), IsNil)
+		c.Assert(ioutil.WriteFile(file, []byte(content), 0644), IsNil)
+	}
+
+	removed, err := Clean(dir)
+	c.Assert(err, IsNil)
+	c.Assert(removed, DeepEquals, []string{
+		filepath.Join(dir, "float64_iter.go"),
+		filepath.Join(dir, "sub/int_iter.go"),
+	})
+
+	for file := range files {
+		_, err := os.Stat(filepath.Join(dir, file))
+		c.Assert(os.IsNotExist(err), Equals, file == "float64_iter.go" || file == "sub/int_iter.go")
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/erizocosmico/go-itergen"
+)
+
+// cleanCommand removes the generated files.
+type cleanCommand struct{}
+
+func (c *cleanCommand) Execute(args []string) error {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	for _, root := range args {
+		removed, err := generator.Clean(root)
+		for _, file := range removed {
+			fmt.Println("removed", file)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
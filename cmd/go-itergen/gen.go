@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/erizocosmico/go-itergen"
+	"github.com/jessevdk/go-flags"
+)
+
+// genCommand generates the code of iterables.
+type genCommand struct {
+	generator.Generator
+	Types      []string `short:"t" long:"type" description:"type to generate the code for, can be given many times to generate several iterables with the same options"`
+	SingleFile bool     `long:"single-file" description:"write all the iterables to a single file, itergen_iter.go by default"`
+	Config     string   `long:"config" description:"generate all the iterables in the given config file, itergen.json in --dir is used if no type is given"`
+
+	plugins *pluginFlags
+	command *flags.Command
+}
+
+func (o *genCommand) Execute(args []string) error {
+	var err error
+	if o.Plugins, err = o.plugins.enabled(); err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		if len(o.Types) > 0 || o.Config != "" {
+			return errors.New("packages can not be given along with -t or --config")
+		}
+		if given := givenOptions(o.command.Group, "force", "check"); len(given) > 0 {
+			return fmt.Errorf("%s can not be given along with packages, the options of their iterables are in their config files and annotations", strings.Join(given, ", "))
+		}
+		return o.generatePackages(args)
+	}
+
+	if o.Config != "" {
+		return o.generateConfig(o.Config, "config")
+	}
+
+	if len(o.Types) > 0 {
+		return o.GenerateTypes(o.Types, o.SingleFile)
+	}
+
+	if o.SingleFile {
+		return errors.New("no type given, use -t to give the types to write to a single file")
+	}
+
+	config := filepath.Join(o.Dir, generator.DefaultConfigFile)
+	if _, err := os.Stat(config); err != nil {
+		return errors.New("no type given, use -t, a config file or give the packages to generate")
+	}
+
+	return o.generateConfig(config, "dir")
+}
+
+// generateConfig generates the iterables in the given config file, which is
+// given with the option with the given long name.
+func (o *genCommand) generateConfig(file, option string) error {
+	if given := givenOptions(o.command.Group, option, "force", "check"); len(given) > 0 {
+		return fmt.Errorf("%s can not be given along with the config file %s, the options of its iterables are set in it", strings.Join(given, ", "), file)
+	}
+
+	if o.Check {
+		return generator.CheckConfig(file)
+	}
+	return generator.GenerateConfig(file, o.Force)
+}
+
+func (o *genCommand) generatePackages(patterns []string) error {
+	var (
+		results []generator.PackageResult
+		err     error
+	)
+
+	if o.Check {
+		results, err = generator.CheckPackages(patterns)
+	} else {
+		results, err = generator.GeneratePackages(patterns, o.Force)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			fmt.Printf("FAIL\t%s\n\t%s\n", r.Dir, strings.Replace(r.Err.Error(), "\n", "\n\t", -1))
+			continue
+		}
+
+		fmt.Printf("ok\t%s\t%d files\n", r.Dir, len(r.Files))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d packages failed", failed, len(results))
+	}
+
+	return nil
+}
+
+// givenOptions returns the options of the group and its subgroups given in
+// the command line but the allowed ones, which are given by long name.
+func givenOptions(group *flags.Group, allowed ...string) []string {
+	var given []string
+	for _, opt := range group.Options() {
+		if opt.IsSet() && !isAllowed(opt.LongName, allowed) {
+			given = append(given, "--"+opt.LongName)
+		}
+	}
+
+	for _, g := range group.Groups() {
+		given = append(given, givenOptions(g, allowed...)...)
+	}
+
+	return given
+}
+
+func isAllowed(name string, allowed []string) bool {
+	for _, a := range allowed {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/erizocosmico/go-itergen"
+)
+
+// initCommand writes a starter config for the types of a package.
+type initCommand struct {
+	Dir        string `long:"dir" default:"." description:"directory of the package"`
+	Directives bool   `long:"directives" description:"write a file with go:generate directives instead of a config file"`
+	Force      bool   `long:"force" description:"overwrite the file if it already exists"`
+}
+
+func (c *initCommand) Execute(args []string) error {
+	config, err := generator.SuggestConfig(c.Dir)
+	if err != nil {
+		return err
+	}
+
+	var file string
+	if c.Directives {
+		file, err = generator.WriteDirectives(c.Dir, config, c.Force)
+	} else {
+		file, err = generator.WriteConfig(c.Dir, config, c.Force)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, opts := range config.Iterables {
+		fmt.Println(generator.New(opts).Command())
+	}
+
+	fmt.Println("written", file)
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/erizocosmico/go-itergen"
+)
+
+// listOpsCommand lists the operations that can be generated.
+type listOpsCommand struct {
+	Templates string `long:"templates" description:"also list the operations defined in the given templates directory"`
+}
+
+func (c *listOpsCommand) Execute(args []string) error {
+	ops := generator.Operations()
+	if c.Templates != "" {
+		plugins, err := generator.LoadPlugins(c.Templates)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range plugins {
+			ops = append(ops, p.Operation)
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "OPERATION\tSLICE\tCHAN\tDESCRIPTION")
+	for _, op := range ops {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", op.Name, yesNo(op.Slice), yesNo(op.Chan), op.Description)
+	}
+	return w.Flush()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -4,26 +4,61 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/erizocosmico/go-itergen"
-	_ "github.com/erizocosmico/go-itergen/statik"
 	"github.com/jessevdk/go-flags"
 )
 
+// defaultCommand is the command run when none is given, so go-itergen can be
+// run with just the options to generate code.
+const defaultCommand = "gen"
+
 func main() {
-	cmd := new(generator.Generator)
-	parser := flags.NewParser(cmd, flags.Default)
-	_, err := parser.Parse()
+	args := os.Args[1:]
+	plugins, err := newPluginFlags(args)
 	if err != nil {
-		if _, ok := err.(*flags.Error); ok {
-			parser.WriteHelp(os.Stdout)
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	parser := flags.NewParser(nil, flags.HelpFlag|flags.PassDoubleDash)
+	genCmd := &genCommand{plugins: plugins}
+	gen, _ := parser.AddCommand("gen", "Generate iterables", "Generate the iterables of the given types, config file or packages.", genCmd)
+	genCmd.command = gen
+	parser.AddCommand("init", "Write a starter config", "Write a config file, or a file with go:generate directives, with the iterables suggested for the named types of a package.", new(initCommand))
+	parser.AddCommand("list-ops", "List the operations", "List the operations that can be generated and the iterables that support them.", new(listOpsCommand))
+	plan, _ := parser.AddCommand("plan", "Show the code to generate", "Print as JSON the files, types and methods that would be generated.", &planCommand{plugins: plugins})
+	parser.AddCommand("watch", "Regenerate on changes", "Regenerate the iterables of the given packages, ./... by default, every time their config files or annotated sources change.", new(watchCommand))
+	templates, _ := parser.AddCommand("templates", "Work with templates", "Work with the templates the code is generated from.", new(templatesCommand))
+	templates.AddCommand("dump", "Write the built-in templates", "Write the built-in templates to the given directory, the current one by default, to customize them and use them with --templates.", new(templatesDumpCommand))
+	parser.AddCommand("clean", "Remove generated files", "Remove all the files generated by go-itergen in the given directory trees, the current one by default.", new(cleanCommand))
+
+	for _, cmd := range []*flags.Command{gen, plan} {
+		if err := plugins.addTo(cmd); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
+	}
 
-		os.Exit(1)
+	if len(args) == 0 || !isCommandOrHelp(parser, args[0]) {
+		args = append([]string{defaultCommand}, args...)
 	}
 
-	err = cmd.Generate()
-	if err != nil {
+	if _, err := parser.ParseArgs(args); err != nil {
+		if flagsErr, ok := err.(*flags.Error); ok {
+			if flagsErr.Type == flags.ErrHelp {
+				fmt.Println(err)
+				return
+			}
+
+			fmt.Fprintln(os.Stderr, err)
+			parser.WriteHelp(os.Stdout)
+			os.Exit(1)
+		}
+
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
+
+func isCommandOrHelp(parser *flags.Parser, arg string) bool {
+	return parser.Find(arg) != nil || arg == "-h" || arg == "--help"
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/erizocosmico/go-itergen"
+)
+
+// planCommand prints the code that would be generated.
+type planCommand struct {
+	generator.Generator
+	Types  []string `short:"t" long:"type" description:"type to plan the code for, can be given many times"`
+	Config string   `long:"config" description:"plan all the iterables in the given config file, itergen.json in --dir is used if no type is given"`
+
+	plugins *pluginFlags
+}
+
+func (c *planCommand) Execute(args []string) error {
+	var err error
+	if c.Plugins, err = c.plugins.enabled(); err != nil {
+		return err
+	}
+
+	plans, err := c.plans()
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(plans)
+}
+
+func (c *planCommand) plans() ([]*generator.Plan, error) {
+	if c.Config != "" {
+		return generator.PlanConfig(c.Config)
+	}
+
+	if len(c.Types) == 0 {
+		config := filepath.Join(c.Dir, generator.DefaultConfigFile)
+		if _, err := os.Stat(config); err != nil {
+			return nil, errors.New("no type given, use -t or a config file")
+		}
+		return generator.PlanConfig(config)
+	}
+
+	var plans []*generator.Plan
+	for _, t := range c.Types {
+		g := c.Generator
+		g.RawType = t
+		plan, err := g.Plan()
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
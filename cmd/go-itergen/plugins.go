@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/erizocosmico/go-itergen"
+	"github.com/jessevdk/go-flags"
+)
+
+// pluginFlags are the options of the operations that are not built in, which
+// are the registered ones and the ones defined in the templates directory
+// given with --templates. They are only known once the directory is read, so
+// the struct holding their values is built at runtime.
+type pluginFlags struct {
+	plugins []generator.Operation
+	// values points to a struct with a bool field for every plugin followed
+	// by a string field for each of its parameters.
+	values reflect.Value
+}
+
+// newPluginFlags returns the options of the registered operations that are
+// not built in and the ones in the templates directory given in args, if
+// any.
+func newPluginFlags(args []string) (*pluginFlags, error) {
+	var plugins []generator.Operation
+	for _, op := range generator.Operations() {
+		if !op.IsBuiltin() {
+			plugins = append(plugins, op)
+		}
+	}
+
+	if dir := templatesArg(args); dir != "" {
+		loaded, err := generator.LoadPlugins(dir)
+		if err != nil {
+			return nil, fmt.Errorf("--templates %q: %s", dir, err)
+		}
+
+		for _, p := range loaded {
+			plugins = append(plugins, p.Operation)
+		}
+	}
+
+	var fields []reflect.StructField
+	for i, p := range plugins {
+		description := p.Description
+		if description == "" {
+			description = "generate the " + p.Name + " operation"
+		}
+
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Op%d", i),
+			Type: reflect.TypeOf(false),
+			Tag:  reflect.StructTag(fmt.Sprintf("long:%q description:%q", p.Name, description)),
+		})
+
+		for j, param := range p.Params {
+			fields = append(fields, reflect.StructField{
+				Name: fmt.Sprintf("Op%dParam%d", i, j),
+				Type: reflect.TypeOf(""),
+				Tag:  reflect.StructTag(fmt.Sprintf("long:%q description:%q", p.Name+"-"+param, param+" type of --"+p.Name)),
+			})
+		}
+	}
+
+	return &pluginFlags{plugins, reflect.New(reflect.StructOf(fields))}, nil
+}
+
+// templatesArg returns the value of the --templates option in args.
+func templatesArg(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		if strings.HasPrefix(arg, "--templates=") {
+			return strings.TrimPrefix(arg, "--templates=")
+		}
+
+		if arg == "--templates" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	return ""
+}
+
+// addTo adds the options of the plugins to the given command.
+func (f *pluginFlags) addTo(cmd *flags.Command) error {
+	if len(f.plugins) == 0 {
+		return nil
+	}
+
+	_, err := cmd.AddGroup("Operations defined in --templates or registered", "", f.values.Interface())
+	return err
+}
+
+// enabled returns the enabled plugins with the values of their parameters,
+// as expected by generator.Options.Plugins.
+func (f *pluginFlags) enabled() (map[string]map[string]string, error) {
+	if len(f.plugins) == 0 {
+		return nil, nil
+	}
+
+	var (
+		result = make(map[string]map[string]string)
+		v      = f.values.Elem()
+		field  = 0
+	)
+
+	for _, p := range f.plugins {
+		enabled := v.Field(field).Bool()
+		field++
+
+		params := make(map[string]string)
+		for _, param := range p.Params {
+			if value := v.Field(field).String(); value != "" {
+				if !enabled {
+					return nil, fmt.Errorf("--%s-%s can only be given along with --%s", p.Name, param, p.Name)
+				}
+				params[param] = value
+			}
+			field++
+		}
+
+		if enabled {
+			result[p.Name] = params
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return result, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/erizocosmico/go-itergen"
+)
+
+// templatesCommand groups the commands to work with templates.
+type templatesCommand struct{}
+
+// templatesDumpCommand writes the built-in templates to a directory.
+type templatesDumpCommand struct {
+	Force bool `long:"force" description:"overwrite the templates that already exist"`
+}
+
+func (c *templatesDumpCommand) Execute(args []string) error {
+	dir := "."
+	switch len(args) {
+	case 0:
+	case 1:
+		dir = args[0]
+	default:
+		return fmt.Errorf("expecting a single directory, %d given", len(args))
+	}
+
+	files, err := generator.DumpTemplates(dir, c.Force)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		fmt.Println("written", file)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/erizocosmico/go-itergen"
+)
+
+// watchCommand regenerates the iterables of packages when their sources
+// change.
+type watchCommand struct {
+	Interval time.Duration `long:"interval" default:"1s" description:"time between checks for changes"`
+	Force    bool          `long:"force" description:"overwrite files even if they were not generated by go-itergen"`
+}
+
+func (c *watchCommand) Execute(args []string) error {
+	if len(args) == 0 {
+		args = []string{"./..."}
+	}
+
+	w := &generator.Watcher{
+		Patterns: args,
+		Interval: c.Interval,
+		Force:    c.Force,
+		Report:   printWatchResult,
+	}
+
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	w.Watch(stop)
+	return nil
+}
+
+func printWatchResult(r generator.PackageResult) {
+	now := time.Now().Format("15:04:05")
+	if r.Err != nil {
+		fmt.Printf("%s FAIL\t%s\n\t%s\n", now, r.Dir, strings.Replace(r.Err.Error(), "\n", "\n\t", -1))
+		return
+	}
+
+	fmt.Printf("%s ok\t%s\t%d files\n", now, r.Dir, len(r.Files))
+}
@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const commandName = "go-itergen"
+
+// Command returns the go-itergen command that generates the same code as
+// the generator.
+func (g *Generator) Command() string {
+	return g.command()
+}
+
+// command returns the go-itergen command that generates the same code as
+// the generator when run in the package directory. Options tagged with
+// header:"-" are not part of it, and paths are relative to the package
+// directory, so --dir is not either.
+func (g *Generator) command() string {
+	if g.origin != "" {
+		return g.origin
+	}
+
+	return g.commandFor([]string{g.RawType})
+}
+
+// Directive returns the go:generate directive that generates the same code
+// as the generator. go generate does not run the command in a shell, so its
+// arguments are quoted as Go strings instead.
+func (g *Generator) Directive() string {
+	return "//go:generate " + strings.Join(g.commandArgs([]string{g.RawType}, strconv.Quote), " ")
+}
+
+// commandFor returns the go-itergen command that generates the code for the
+// given types with the options of the generator, followed by the given extra
+// arguments.
+func (g *Generator) commandFor(types []string, extra ...string) string {
+	args := g.commandArgs(types, shellQuote)
+	return strings.Join(append(args, extra...), " ")
+}
+
+// commandArgs returns the go-itergen command that generates the code for the
+// given types with the options of the generator, with the values that need
+// it quoted with the given function.
+func (g *Generator) commandArgs(types []string, quote func(string) string) []string {
+	args := []string{commandName}
+
+	v := reflect.ValueOf(g).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		long := field.Tag.Get("long")
+		if long == "" || field.Tag.Get("header") == "-" {
+			continue
+		}
+
+		if field.Name == "RawType" {
+			for _, t := range types {
+				args = append(args, commandArg(long, t, quote))
+			}
+			continue
+		}
+
+		switch value := v.Field(i).Interface().(type) {
+		case bool:
+			if value {
+				args = append(args, "--"+long)
+			}
+		case string:
+			switch field.Name {
+			case "Dir":
+				continue
+			case "Output", "Templates":
+				value = g.relPath(value)
+			}
+
+			if value != "" {
+				args = append(args, commandArg(long, value, quote))
+			}
+		case []string:
+			for _, s := range value {
+				args = append(args, commandArg(long, s, quote))
+			}
+		default:
+			panic(fmt.Sprintf("unsupported option type %T", value))
+		}
+	}
+
+	return append(args, g.pluginArgs(quote)...)
+}
+
+// relPath returns the given path relative to the package directory, or as
+// it is if it is stdout or can not be made relative.
+func (g *Generator) relPath(file string) string {
+	if file == "" || file == stdout {
+		return file
+	}
+
+	dir, err := filepath.Abs(g.dir())
+	if err != nil {
+		return file
+	}
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return file
+	}
+
+	rel, err := filepath.Rel(dir, abs)
+	if err != nil {
+		return file
+	}
+	return rel
+}
+
+// commandArg returns the option with the given value, quoted with the given
+// function if needed.
+func commandArg(long, value string, quote func(string) string) string {
+	if strings.IndexFunc(value, isUnsafeArgRune) >= 0 || value == "" {
+		value = quote(value)
+	}
+	return "--" + long + "=" + value
+}
+
+// shellQuote quotes the given value with single quotes, so that nothing in it
+// is expanded by the shell. Single quotes in it end the quoted string, are
+// escaped with a backslash and start a new one.
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
+
+func isUnsafeArgRune(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return false
+	case strings.ContainsRune("_-./:=+,@", r):
+		return false
+	default:
+		return true
+	}
+}
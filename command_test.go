@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type CommandSuite struct{}
+
+var _ = Suite(&CommandSuite{})
+
+func (s *CommandSuite) TestCommand(c *C) {
+	g := New(Options{
+		Type:    "github.com/foo/go-bar as bar:chan bar.X",
+		Package: "foo",
+		Name:    "Bars",
+		Map:     []string{"int", "Str=string"},
+		Reduce:  []string{`struct{ X int "x" }`},
+		Filter:  true,
+		Concat:  true,
+		Array:   true,
+		Output:  "-",
+		Force:   true,
+	})
+
+	c.Assert(g.command(), Equals, `go-itergen --type='github.com/foo/go-bar as bar:chan bar.X' --pkg=foo --name=Bars --map=int --map=Str=string --filter --concat --reduce='struct{ X int "x" }' --array --output=-`)
+	c.Assert(g.Directive(), Equals, `//go:generate go-itergen --type="github.com/foo/go-bar as bar:chan bar.X" --pkg=foo --name=Bars --map=int --map=Str=string --filter --concat --reduce="struct{ X int \"x\" }" --array --output=-`)
+}
+
+func (s *CommandSuite) TestCommandQuoting(c *C) {
+	g := New(Options{
+		Type:   "struct{ X int `json:\"x\"` }",
+		Map:    []string{"$HOME", "Quote=struct{ X int `json:\"it's\"` }"},
+		Filter: true,
+	})
+
+	c.Assert(g.command(), Equals, `go-itergen --type='struct{ X int `+"`"+`json:"x"`+"`"+` }' --map='$HOME' --map='Quote=struct{ X int `+"`"+`json:"it'\''s"`+"`"+` }' --filter`)
+}
+
+func (s *CommandSuite) TestCommandPaths(c *C) {
+	dir := c.MkDir()
+	tcs := []struct {
+		opts    Options
+		command string
+	}{
+		{Options{Type: "int", Dir: dir}, "go-itergen --type=int"},
+		{Options{Type: "int", Dir: dir, Output: filepath.Join(dir, "ints.go")}, "go-itergen --type=int --output=ints.go"},
+		{Options{Type: "int", Output: filepath.Join(dir, "ints.go")}, "go-itergen --type=int --output=ints.go"},
+		{Options{Type: "int", Dir: dir, Output: filepath.Join(dir, "gen", "ints.go")}, "go-itergen --type=int --output=gen/ints.go"},
+		{Options{Type: "int", Dir: dir, Templates: filepath.Join(dir, "..", "tpl")}, "go-itergen --type=int --templates=../tpl"},
+		{Options{Type: "int", Dir: dir, Output: "-"}, "go-itergen --type=int --output=-"},
+	}
+
+	for _, tc := range tcs {
+		c.Assert(New(tc.opts).command(), Equals, tc.command)
+	}
+}
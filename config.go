@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultConfigFile is the config file used when no type is given.
+const DefaultConfigFile = "itergen.json"
+
+// Config lists all the iterables to generate for a package.
+type Config struct {
+	// Package is the package of the generated code. If empty, it is inferred
+	// from $GOPACKAGE or the files in the directory of the config file.
+	Package string `json:"package,omitempty"`
+	// Templates is the templates directory of the iterables that do not
+	// give one. Templates directories are relative to the directory of the
+	// config file.
+	Templates string `json:"templates,omitempty"`
+	// Iterables are the options of all the iterables to generate. Their
+	// outputs are relative to the directory of the config file.
+	Iterables []Options `json:"iterables"`
+}
+
+// LoadConfig reads the config in the given file.
+func LoadConfig(file string) (*Config, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var config Config
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %s", file, err)
+	}
+
+	return &config, nil
+}
+
+// GenerateConfig generates all the iterables in the given config file in the
+// directory of the file, and removes the files generated by a previous run
+// for iterables that are no longer in the config. If force is true, files
+// not generated by go-itergen are overwritten.
+func GenerateConfig(file string, force bool) error {
+	_, err := generateConfig(file, force, false, false, nil)
+	return atGenerateLine(err)
+}
+
+// CheckConfig returns an error showing the differences with the files on disk
+// if generating the iterables in the given config file would change, create
+// or remove any file. Nothing is written.
+func CheckConfig(file string) error {
+	_, err := generateConfig(file, false, true, false, nil)
+	return atGenerateLine(err)
+}
+
+// generateConfig generates or checks the iterables in the given config file,
+// returning their files. If packages is true, the config is generated along
+// with other packages and GOPACKAGE is ignored. If regenerate is not nil,
+// only the iterables it returns true for are generated, see generateSome.
+func generateConfig(file string, force, check, packages bool, regenerate func(*Generator) bool) ([]string, error) {
+	config, err := LoadConfig(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		dir         = filepath.Dir(file)
+		gens, names = configGenerators(file, config, force, check)
+	)
+
+	for _, g := range gens {
+		g.ignorePackageEnv = packages
+	}
+
+	// in check mode the files are also returned when they are not up to
+	// date, so the stale ones can be reported too
+	written, kept, err := generateSome(gens, names, regenerate)
+	if err != nil && (!check || written == nil) {
+		return nil, err
+	}
+
+	keep := append(append([]string(nil), written...), kept...)
+	return written, joinErrors(err, removeStale(dir, commandName+" --config="+filepath.Base(file), keep, check))
+}
+
+// configGenerators returns the generators of the iterables in the given
+// config, read from the given file, along with their names for errors.
+func configGenerators(file string, config *Config, force, check bool) ([]*Generator, []string) {
+	var (
+		dir   = filepath.Dir(file)
+		gens  = make([]*Generator, len(config.Iterables))
+		names = make([]string, len(config.Iterables))
+	)
+
+	for i, opts := range config.Iterables {
+		opts.Dir = dir
+		opts.Force = force
+		opts.Check = check
+		if opts.Package == "" {
+			opts.Package = config.Package
+		}
+
+		if opts.Templates == "" {
+			opts.Templates = config.Templates
+		}
+
+		if opts.Templates != "" && !filepath.IsAbs(opts.Templates) {
+			opts.Templates = filepath.Join(dir, opts.Templates)
+		}
+
+		if opts.Output != "" && opts.Output != stdout {
+			opts.Output = filepath.Join(dir, opts.Output)
+		}
+
+		gens[i] = New(opts)
+		gens[i].origin = commandName + " --config=" + filepath.Base(file)
+		names[i] = fmt.Sprintf("%s: iterables[%d]", file, i)
+	}
+
+	return gens, names
+}
+
+// removeStale removes the files in dir generated by the given command that
+// are not in keep. In check mode, it returns an error listing them instead.
+func removeStale(dir, command string, keep []string, check bool) error {
+	kept := make(map[string]bool)
+	for _, file := range keep {
+		kept[filepath.Clean(file)] = true
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var stale []string
+	for _, f := range files {
+		file := filepath.Join(dir, f.Name())
+		if f.IsDir() || filepath.Ext(file) != ".go" || kept[file] {
+			continue
+		}
+
+		generated, cmd, err := readHeader(file)
+		if err != nil {
+			return err
+		}
+
+		if !generated || cmd != command {
+			continue
+		}
+
+		if check {
+			stale = append(stale, fmt.Sprintf("%s is no longer generated and would be removed", file))
+		} else if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+
+	if len(stale) > 0 {
+		return errors.New(strings.Join(stale, "\n"))
+	}
+
+	return nil
+}
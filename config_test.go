@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type ConfigSuite struct {
+	dir string
+}
+
+var _ = Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+}
+
+func (s *ConfigSuite) writeConfig(c *C, config string) string {
+	file := filepath.Join(s.dir, DefaultConfigFile)
+	c.Assert(ioutil.WriteFile(file, []byte(config), 0644), IsNil)
+	return file
+}
+
+func (s *ConfigSuite) exists(file string) bool {
+	_, err := os.Stat(filepath.Join(s.dir, file))
+	return err == nil
+}
+
+func (s *ConfigSuite) TestLoadConfig(c *C) {
+	file := s.writeConfig(c, `{
+		"package": "foo",
+		"iterables": [
+			{"type": "float64", "map": ["int"], "filter": true},
+			{"type": "chan string", "name": "Names", "array": true, "output": "names.go"}
+		]
+	}`)
+
+	config, err := LoadConfig(file)
+	c.Assert(err, IsNil)
+	c.Assert(config, DeepEquals, &Config{
+		Package: "foo",
+		Iterables: []Options{
+			{Type: "float64", Map: []string{"int"}, Filter: true},
+			{Type: "chan string", Name: "Names", Array: true, Output: "names.go"},
+		},
+	})
+
+	file = s.writeConfig(c, `{"iterables": [{"type": "float64", "filtr": true}]}`)
+	_, err = LoadConfig(file)
+	c.Assert(err, ErrorMatches, `invalid config file .*: json: unknown field "filtr"`)
+}
+
+func (s *ConfigSuite) TestGenerateConfig(c *C) {
+	file := s.writeConfig(c, `{
+		"package": "foo",
+		"iterables": [
+			{"type": "float64", "filter": true},
+			{"type": "chan string", "array": true, "output": "names.go"}
+		]
+	}`)
+
+	c.Assert(GenerateConfig(file, false), IsNil)
+	c.Assert(s.exists("float64_iter.go"), Equals, true)
+	c.Assert(s.exists("names.go"), Equals, true)
+
+	generated, command, err := readHeader(filepath.Join(s.dir, "names.go"))
+	c.Assert(err, IsNil)
+	c.Assert(generated, Equals, true)
+	c.Assert(command, Equals, "go-itergen --config=itergen.json")
+
+	// files generated by other commands are never removed
+	other := New(Options{Type: "int", Package: "foo", Filter: true, Dir: s.dir})
+	c.Assert(other.Generate(), IsNil)
+
+	file = s.writeConfig(c, `{
+		"package": "foo",
+		"iterables": [{"type": "float64", "filter": true}]
+	}`)
+
+	c.Assert(GenerateConfig(file, false), IsNil)
+	c.Assert(s.exists("float64_iter.go"), Equals, true)
+	c.Assert(s.exists("names.go"), Equals, false)
+	c.Assert(s.exists("int_iter.go"), Equals, true)
+}
+
+func (s *ConfigSuite) TestGenerateConfigTemplates(c *C) {
+	c.Assert(os.Mkdir(filepath.Join(s.dir, "templates"), 0755), IsNil)
+	text := "type {{.Name}}Iter []{{.Type}}\n\n// custom\n"
+	c.Assert(ioutil.WriteFile(filepath.Join(s.dir, "templates", "type.tgo"), []byte(text), 0644), IsNil)
+
+	file := s.writeConfig(c, `{
+		"package": "foo",
+		"templates": "templates",
+		"iterables": [
+			{"type": "float64", "filter": true},
+			{"type": "int", "filter": true, "templates": "missing"}
+		]
+	}`)
+
+	err := GenerateConfig(file, false)
+	c.Assert(err, ErrorMatches, `.*iterables\[1\]: --templates ".*missing": can not read the templates directory: .*`)
+
+	file = s.writeConfig(c, `{"package": "foo", "templates": "templates", "iterables": [{"type": "float64", "filter": true}]}`)
+	c.Assert(GenerateConfig(file, false), IsNil)
+
+	code, err := ioutil.ReadFile(filepath.Join(s.dir, "float64_iter.go"))
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Matches, "(?s).*// custom\n.*")
+}
+
+func (s *ConfigSuite) TestGenerateConfigValidation(c *C) {
+	file := s.writeConfig(c, `{
+		"package": "foo",
+		"iterables": [
+			{"type": "float64", "filter": true},
+			{"type": "float64", "some": true, "output": "other.go"},
+			{"type": "int", "output": "float64_iter.go"},
+			{"type": "chan int", "some": true}
+		]
+	}`)
+
+	err := GenerateConfig(file, false)
+	c.Assert(err, ErrorMatches, `.*iterables\[1\]: Float64Iter is also generated by .*iterables\[0\]
+.*iterables\[1\]: NewFloat64Iter is also generated by .*iterables\[0\]
+.*iterables\[2\]: file .*float64_iter.go is also generated by .*iterables\[0\]
+.*iterables\[3\]: --some is not supported by chan types, remove it or use a slice type`)
+
+	// nothing is written if any of the iterables is not valid
+	c.Assert(s.exists("float64_iter.go"), Equals, false)
+	c.Assert(s.exists("other.go"), Equals, false)
+}
+
+func (s *ConfigSuite) TestCheckConfig(c *C) {
+	file := s.writeConfig(c, `{
+		"package": "foo",
+		"iterables": [
+			{"type": "float64", "filter": true},
+			{"type": "chan string", "array": true, "output": "names.go"}
+		]
+	}`)
+
+	err := CheckConfig(file)
+	c.Assert(err, ErrorMatches, `(?s).*float64_iter.go is not up to date:.*names.go is not up to date:.*`)
+	c.Assert(s.exists("float64_iter.go"), Equals, false)
+
+	c.Assert(GenerateConfig(file, false), IsNil)
+	c.Assert(CheckConfig(file), IsNil)
+
+	file = s.writeConfig(c, `{
+		"package": "foo",
+		"iterables": [{"type": "float64", "filter": true, "some": true}]
+	}`)
+
+	err = CheckConfig(file)
+	c.Assert(err, ErrorMatches, `(?s).*float64_iter.go is not up to date:.*
+.*names.go is no longer generated and would be removed`)
+	c.Assert(s.exists("names.go"), Equals, true)
+}
@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/types"
+	"path"
+	"sort"
+	"strings"
+)
+
+// describeTypeName is the prefix of the aliases declared to type check the
+// described types along with the package they are generated in.
+const describeTypeName = "itergenDescribe"
+
+// describe fills the kind information of the given resolved types using
+// go/types. Every type is declared as an alias in a file of its own, with the
+// imports of the type. They are checked along with the package in the
+// resolver directory only if any of them refers to its types, so local types
+// can be described too. Type errors are ignored, the types that can not be
+// checked are left undescribed.
+func (r *resolver) describe(defs []*TypeDef) {
+	pkgName := r.pkgName
+	if pkgName == "" {
+		pkgName = "main"
+	}
+
+	var files []*ast.File
+	for _, t := range defs {
+		expr, err := parser.ParseExpr(t.Type)
+		if err == nil && refersToLocalTypes(expr) {
+			files = append(files, r.files...)
+			r.loadImports()
+			break
+		}
+	}
+
+	for i, t := range defs {
+		var src strings.Builder
+		fmt.Fprintf(&src, "package %s\n\n", pkgName)
+		for _, imp := range typeImports(*t) {
+			fmt.Fprintf(&src, "import %s %q\n", imp.Alias, imp.Path)
+		}
+		fmt.Fprintf(&src, "\ntype %s%d = %s\n", describeTypeName, i, t.Type)
+
+		f, err := parser.ParseFile(r.fset, fmt.Sprintf("%s%d.go", describeTypeName, i), src.String(), 0)
+		if err != nil {
+			continue
+		}
+		files = append(files, f)
+	}
+
+	conf := types.Config{Importer: r.importer, Error: func(error) {}}
+	pkg, _ := conf.Check(r.dir, r.fset, files, nil)
+	if pkg == nil {
+		return
+	}
+
+	for i, t := range defs {
+		obj := pkg.Scope().Lookup(fmt.Sprintf("%s%d", describeTypeName, i))
+		if obj == nil {
+			continue
+		}
+
+		typ := types.Unalias(obj.Type())
+		if typ == types.Typ[types.Invalid] {
+			continue
+		}
+
+		describeType(t, typ, typeQualifier(pkg, *t))
+	}
+}
+
+// typeQualifier returns the qualifier of the packages referred to by the
+// given type in the code generated in pkg, which are referred to by their
+// alias if the type imports them with one.
+func typeQualifier(pkg *types.Package, t TypeDef) types.Qualifier {
+	return func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+
+		for _, imp := range typeImports(t) {
+			if imp.Path == p.Path() && imp.Alias != "" {
+				return imp.Alias
+			}
+		}
+		return p.Name()
+	}
+}
+
+// describeType sets the kind information of t, whose type is typ.
+func describeType(t *TypeDef, typ types.Type, qualifier types.Qualifier) {
+	under := typ.Underlying()
+	_, t.IsPointer = under.(*types.Pointer)
+	t.IsComparable = types.Comparable(typ)
+	if basic, ok := under.(*types.Basic); ok {
+		t.IsNumeric = basic.Info()&types.IsNumeric != 0
+		t.IsString = basic.Info()&types.IsString != 0
+	}
+
+	st, ok := under.(*types.Struct)
+	if ptr, isPtr := under.(*types.Pointer); isPtr {
+		st, ok = ptr.Elem().Underlying().(*types.Struct)
+	}
+
+	t.Fields, t.fieldImports = nil, nil
+	if ok {
+		// the packages of the fields are recorded as they are qualified, so
+		// that the generated code can import them if it uses the fields
+		recordImport := func(p *types.Package) string {
+			name := qualifier(p)
+			if name != "" {
+				imp := Import{Path: p.Path()}
+				if name != path.Base(p.Path()) {
+					imp.Alias = name
+				}
+
+				if !containsImport(t.fieldImports, imp) {
+					t.fieldImports = append(t.fieldImports, imp)
+				}
+			}
+			return name
+		}
+
+		for i := 0; i < st.NumFields(); i++ {
+			f := st.Field(i)
+			t.Fields = append(t.Fields, Field{
+				Name:     f.Name(),
+				Type:     types.TypeString(f.Type(), recordImport),
+				Embedded: f.Embedded(),
+			})
+		}
+	}
+
+	t.zero = zeroValue(typ, t.Type)
+}
+
+// zeroValue returns the expression of the zero value of typ, written as expr.
+func zeroValue(typ types.Type, expr string) string {
+	switch under := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case under.Info()&types.IsBoolean != 0:
+			return "false"
+		case under.Info()&types.IsString != 0:
+			return `""`
+		case under.Info()&types.IsNumeric != 0:
+			return "0"
+		case under.Kind() == types.UnsafePointer:
+			return "nil"
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return "nil"
+	case *types.Struct, *types.Array:
+		return expr + "{}"
+	}
+
+	return "*new(" + expr + ")"
+}
+
+// loadImports lists the packages imported by the files of the package in the
+// resolver directory at once, instead of one by one as they are imported.
+func (r *resolver) loadImports() {
+	var paths []string
+	for _, path := range r.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	r.loader.list(r.dir, paths...)
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type DescribeSuite struct {
+	dir string
+}
+
+var _ = Suite(&DescribeSuite{})
+
+const describePkg = `package foo
+
+import tm "time"
+
+type Celsius float64
+
+type Name string
+
+type Order struct {
+	ID      int
+	Created tm.Time
+	Name
+	items   []string
+}
+
+type Handler func()
+`
+
+func (s *DescribeSuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+	c.Assert(ioutil.WriteFile(filepath.Join(s.dir, "foo.go"), []byte(describePkg), 0644), IsNil)
+}
+
+func (s *DescribeSuite) describe(c *C, raw string) TypeDef {
+	g := &Generator{RawType: raw}
+	c.Assert(g.parseTypes(), IsNil)
+	c.Assert(g.resolveTypes(s.dir), IsNil)
+	return g.Type
+}
+
+func (s *DescribeSuite) TestDescribe(c *C) {
+	orderFields := []Field{
+		{Name: "ID", Type: "int"},
+		{Name: "Created", Type: "time.Time"},
+		{Name: "Name", Type: "Name", Embedded: true},
+		{Name: "items", Type: "[]string"},
+	}
+
+	tcs := []struct {
+		raw                               string
+		pointer, comparable, numeric, str bool
+		fields                            []Field
+		zero                              string
+	}{
+		{"int", false, true, true, false, nil, "0"},
+		{"Celsius", false, true, true, false, nil, "0"},
+		{"Name", false, true, false, true, nil, `""`},
+		{"bool", false, true, false, false, nil, "false"},
+		{"Order", false, false, false, false, orderFields, "Order{}"},
+		{"*Order", true, true, false, false, orderFields, "nil"},
+		{"chan Order", false, false, false, false, orderFields, "Order{}"},
+		{"Handler", false, false, false, false, nil, "nil"},
+		{"[]int", false, false, false, false, nil, "nil"},
+		{"[2]string", false, true, false, false, nil, "[2]string{}"},
+		{"struct{ X int }", false, true, false, false, []Field{{Name: "X", Type: "int"}}, "struct{ X int }{}"},
+		{"io.Reader", false, true, false, false, nil, "nil"},
+		{"strings as str:struct{ B *str.Builder }", false, true, false, false, []Field{{Name: "B", Type: "*str.Builder"}}, "struct{ B *str.Builder }{}"},
+	}
+
+	for _, tc := range tcs {
+		t := s.describe(c, tc.raw)
+		comment := Commentf(tc.raw)
+		c.Assert(t.IsPointer, Equals, tc.pointer, comment)
+		c.Assert(t.IsComparable, Equals, tc.comparable, comment)
+		c.Assert(t.IsNumeric, Equals, tc.numeric, comment)
+		c.Assert(t.IsString, Equals, tc.str, comment)
+		c.Assert(t.Fields, DeepEquals, tc.fields, comment)
+		c.Assert(t.zero, Equals, tc.zero, comment)
+	}
+}
+
+func (s *DescribeSuite) TestDescribeTargets(c *C) {
+	g := &Generator{RawType: "Order", Map: []string{"Celsius"}, Reduce: []string{"time:time.Duration"}}
+	c.Assert(g.parseTypes(), IsNil)
+	c.Assert(g.resolveTypes(s.dir), IsNil)
+	c.Assert(g.MapResults[0].IsNumeric, Equals, true)
+	c.Assert(g.ReduceTypes[0].IsNumeric, Equals, true)
+	c.Assert(g.ReduceTypes[0].zero, Equals, "0")
+}
+
+func (s *DescribeSuite) TestDescribeNewPackage(c *C) {
+	g := &Generator{RawType: "float64"}
+	c.Assert(g.parseTypes(), IsNil)
+	c.Assert(g.resolveTypes(filepath.Join(s.dir, "missing")), IsNil)
+	c.Assert(g.Type.IsNumeric, Equals, true)
+}
@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around the changes in
+// a unified diff.
+const diffContext = 3
+
+type diffLine struct {
+	// op is ' ' for unchanged lines, '-' for removed lines and '+' for
+	// added lines.
+	op   byte
+	text string
+}
+
+// unifiedDiff returns the unified diff from the old to the new content of the
+// given file, or an empty string if they are equal.
+func unifiedDiff(file string, old, new []byte) string {
+	if bytes.Equal(old, new) {
+		return ""
+	}
+
+	lines := diffLines(splitLines(old), splitLines(new))
+
+	// oldPos and newPos are the number of old and new lines before each
+	// line of the diff.
+	oldPos := make([]int, len(lines)+1)
+	newPos := make([]int, len(lines)+1)
+	for i, l := range lines {
+		oldPos[i+1], newPos[i+1] = oldPos[i], newPos[i]
+		if l.op != '+' {
+			oldPos[i+1]++
+		}
+		if l.op != '-' {
+			newPos[i+1]++
+		}
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "--- %s\n+++ %s (generated)\n", file, file)
+
+	for i := 0; i < len(lines); {
+		if lines[i].op == ' ' {
+			i++
+			continue
+		}
+
+		last := i
+		for j := i; j < len(lines) && j-last <= 2*diffContext; j++ {
+			if lines[j].op != ' ' {
+				last = j
+			}
+		}
+
+		start, end := i-diffContext, last+diffContext+1
+		if start < 0 {
+			start = 0
+		}
+		if end > len(lines) {
+			end = len(lines)
+		}
+		fmt.Fprintf(
+			&buf, "@@ -%s +%s @@\n",
+			hunkRange(oldPos[start], oldPos[end]-oldPos[start]),
+			hunkRange(newPos[start], newPos[end]-newPos[start]),
+		)
+
+		for _, l := range lines[start:end] {
+			fmt.Fprintf(&buf, "%c%s\n", l.op, l.text)
+		}
+
+		i = end
+	}
+
+	return buf.String()
+}
+
+// hunkRange returns the range of a hunk with the given number of lines
+// starting after the given line.
+func hunkRange(before, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	return fmt.Sprintf("%d,%d", before+1, n)
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
+
+// diffLines returns the lines of the diff from old to new, using their
+// longest common subsequence as the unchanged lines.
+func diffLines(old, new []string) []diffLine {
+	// lcs[i][j] is the length of the longest common subsequence of old[i:]
+	// and new[j:].
+	lcs := make([][]int, len(old)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(new)+1)
+	}
+
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(new) - 1; j >= 0; j-- {
+			if old[i] == new[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var (
+		lines []diffLine
+		i, j  int
+	)
+
+	for i < len(old) || j < len(new) {
+		switch {
+		case i < len(old) && j < len(new) && old[i] == new[j]:
+			lines = append(lines, diffLine{' ', old[i]})
+			i++
+			j++
+		case i < len(old) && (j == len(new) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', old[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', new[j]})
+			j++
+		}
+	}
+
+	return lines
+}
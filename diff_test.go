@@ -0,0 +1,73 @@
+package generator
+
+import . "gopkg.in/check.v1"
+
+type DiffSuite struct{}
+
+var _ = Suite(&DiffSuite{})
+
+func (s *DiffSuite) TestUnifiedDiff(c *C) {
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+
+	tcs := []struct {
+		new  string
+		diff string
+	}{
+		{old, ""},
+		{
+			"a\nb\nc\nd\ne\nF\ng\nh\ni\nj\nk\nl\nm\n",
+			`--- f.go
++++ f.go (generated)
+@@ -3,7 +3,7 @@
+ c
+ d
+ e
+-f
++F
+ g
+ h
+ i
+`,
+		},
+		{
+			"x\na\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n",
+			`--- f.go
++++ f.go (generated)
+@@ -1,3 +1,4 @@
++x
+ a
+ b
+ c
+@@ -10,4 +11,3 @@
+ j
+ k
+ l
+-m
+`,
+		},
+		{
+			"",
+			`--- f.go
++++ f.go (generated)
+@@ -1,13 +0,0 @@
+-a
+-b
+-c
+-d
+-e
+-f
+-g
+-h
+-i
+-j
+-k
+-l
+-m
+`,
+		},
+	}
+
+	for _, tc := range tcs {
+		c.Assert(unifiedDiff("f.go", []byte(old), []byte(tc.new)), Equals, tc.diff)
+	}
+}
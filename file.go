@@ -1,12 +1,21 @@
 package generator
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"regexp"
 	"strings"
 )
 
+// generatedHeader is the comment all the generated files start with.
+const generatedHeader = "// Code generated by go-itergen. DO NOT EDIT."
+
+// generatedPrefix identifies the generated header of go-itergen files.
+const generatedPrefix = "// Code generated by go-itergen"
+
 var fileNameRegex = regexp.MustCompile(`[^a-zA-Z0-9]`)
 
 func fileify(t string) string {
@@ -29,3 +38,83 @@ func write(file string, code []byte) error {
 
 	return ioutil.WriteFile(file, code, 0644)
 }
+
+// isGenerated reports whether the given file was generated by go-itergen,
+// that is, if it has the go-itergen generated header before its package
+// clause.
+func isGenerated(file string) (bool, error) {
+	generated, _, err := readHeader(file)
+	return generated, err
+}
+
+// readHeader reads the header of the given file, returning whether it was
+// generated by go-itergen and, if so, the command that generated it.
+func readHeader(file string) (generated bool, command string, err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return false, "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, generatedPrefix):
+			generated = true
+		case generated && strings.HasPrefix(line, "// "+commandName):
+			command = strings.TrimPrefix(line, "// ")
+		case strings.HasPrefix(line, "package "):
+			return generated, command, nil
+		}
+	}
+
+	return generated, command, scanner.Err()
+}
+
+// checkOverwrite returns an error if the given file exists and was not
+// generated by go-itergen, so hand-written code is never overwritten.
+func checkOverwrite(file string) error {
+	generated, err := isGenerated(file)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if !generated {
+		return fmt.Errorf("refusing to overwrite %s, it was not generated by go-itergen, use --force to overwrite it anyway", file)
+	}
+
+	return nil
+}
+
+// writeOutput writes the code to the given file or to stdout if the file is
+// "-".
+func writeOutput(file string, code []byte) error {
+	if file == stdout {
+		_, err := os.Stdout.Write(code)
+		return err
+	}
+
+	return write(file, code)
+}
+
+// checkOutput returns an error showing the differences between the file and
+// the given code, if any.
+func checkOutput(file string, code []byte) error {
+	if file == stdout {
+		return errors.New("--check can not be used when writing to stdout")
+	}
+
+	current, err := ioutil.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if diff := unifiedDiff(file, current, code); diff != "" {
+		return fmt.Errorf("%s is not up to date:\n%s", file, strings.TrimSuffix(diff, "\n"))
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package generator
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	. "gopkg.in/check.v1"
@@ -54,3 +55,37 @@ func (s *FileSuite) TestWrite(c *C) {
 
 	c.Assert(deleteIfExists(f), IsNil)
 }
+
+func (s *FileSuite) TestIsGenerated(c *C) {
+	dir := c.MkDir()
+	tcs := []struct {
+		content   string
+		generated bool
+	}{
+		{"// Code generated by go-itergen. DO NOT EDIT.\n\npackage foo\n", true},
+		{"// +build foo\n\n// Code generated by go-itergen. DO NOT EDIT.\npackage foo\n", true},
+		{"// Code generated by stringer. DO NOT EDIT.\n\npackage foo\n", false},
+		{"package foo\n\n// Code generated by go-itergen. DO NOT EDIT.\n", false},
+		{"package foo\n", false},
+	}
+
+	for i, tc := range tcs {
+		file := filepath.Join(dir, "foo.go")
+		c.Assert(ioutil.WriteFile(file, []byte(tc.content), 0644), IsNil)
+		generated, err := isGenerated(file)
+		c.Assert(err, IsNil)
+		c.Assert(generated, Equals, tc.generated, Commentf("case %d", i))
+	}
+}
+
+func (s *FileSuite) TestCheckOverwrite(c *C) {
+	dir := c.MkDir()
+	c.Assert(checkOverwrite(filepath.Join(dir, "missing.go")), IsNil)
+
+	file := filepath.Join(dir, "foo.go")
+	c.Assert(ioutil.WriteFile(file, []byte("package foo\n"), 0644), IsNil)
+	c.Assert(checkOverwrite(file), ErrorMatches, "refusing to overwrite .*foo.go, it was not generated by go-itergen, .*")
+
+	c.Assert(ioutil.WriteFile(file, []byte(generatedHeader+"\n\npackage foo\n"), 0644), IsNil)
+	c.Assert(checkOverwrite(file), IsNil)
+}
@@ -1,18 +1,13 @@
 package generator
 
-var generatedImport1 = `import (
-  "errors"
-)
-`
+var generatedImport1 = "\n"
 
 var generatedImport2 = `import (
-  "errors"
   "os"
 )
 `
 
 var generatedImport3 = `import (
-  "errors"
   "foo"
   "github.com/foo/bar"
   "os"
@@ -26,6 +21,12 @@ func NewOsFileIter(items ...*os.File) OsFileIter {
 }
 `
 
+var generatedChanType = `type Float64ChanIter chan float64
+`
+
+var generatedRecvChanType = `type Float64ChanIter <-chan float64
+`
+
 var generatedMap = `
 type Float64IterMapResult []interface{}
 
@@ -188,3 +189,25 @@ func (i Float64Iter) ReduceString(fn func(current float64, acc string, index int
 }
 
 `
+
+var generatedSource = `// Code generated by go-itergen. DO NOT EDIT.
+//
+// go-itergen --type=float64 --pkg=foo --some
+
+package foo
+
+type Float64Iter []float64
+
+func NewFloat64Iter(items ...float64) Float64Iter {
+	return Float64Iter(items)
+}
+
+func (i Float64Iter) Some(fn func(float64) bool) bool {
+	for _, item := range i {
+		if fn(item) {
+			return true
+		}
+	}
+	return false
+}
+`
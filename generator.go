@@ -4,156 +4,164 @@ package generator
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"go/format"
+	"go/token"
 	"io"
 	"path/filepath"
-	"sort"
 	"strings"
 	"text/template"
 )
 
 // Generator generates functions for iterable types based on the options received
 type Generator struct {
-	RawType string   `short:"t" long:"type" description:"type to generate the code for" required:"true"`
-	Package string   `long:"pkg" description:"package of the resultant file" required:"true"`
-	Map     []string `long:"map" description:"generate Map function with transformer for given type"`
-	Filter  bool     `long:"filter" description:"generate Filter function"`
-	All     bool     `long:"all" description:"generate All function"`
-	Some    bool     `long:"some" description:"generate Some function"`
-	ForEach bool     `long:"foreach" description:"generate ForEach function"`
-	Concat  bool     `long:"concat" description:"generate Concat function"`
-	Find    bool     `long:"find" description:"generate Find function"`
-	Reverse bool     `long:"reverse" description:"generate Reverse function"`
-	Splice  bool     `long:"splice" description:"generate Splice function"`
-	Reduce  []string `long:"reduce" description:"generate Reduce function for given type"`
-	Array   bool     `long:"array" description:"generate Array function for channel type"`
+	// RawType is given with -t by the command, which accepts many types.
+	RawType   string   `long:"type" no-flag:"true"`
+	Package   string   `long:"pkg" description:"package of the resultant file, inferred from $GOPACKAGE or the existing files if not given"`
+	Name      string   `long:"name" description:"name of the iterable, instead of the one derived from the type"`
+	Map       []string `long:"map" description:"generate Map function with transformer for given type, optionally named with Name=type"`
+	Filter    bool     `long:"filter" description:"generate Filter function"`
+	All       bool     `long:"all" description:"generate All function"`
+	Some      bool     `long:"some" description:"generate Some function"`
+	ForEach   bool     `long:"foreach" description:"generate ForEach function"`
+	Concat    bool     `long:"concat" description:"generate Concat function"`
+	Find      bool     `long:"find" description:"generate Find function"`
+	Reverse   bool     `long:"reverse" description:"generate Reverse function"`
+	Splice    bool     `long:"splice" description:"generate Splice function"`
+	Reduce    []string `long:"reduce" description:"generate Reduce function for given type, optionally named with Name=type"`
+	Array     bool     `long:"array" description:"generate Array function for channel type"`
+	Preset    string   `long:"preset" description:"enable the operations of a preset supported by the type: all, functional or pipeline"`
+	AllOps    bool     `long:"all-ops" description:"enable all the operations supported by the type"`
+	Templates string   `long:"templates" description:"directory with *.tgo templates overriding the built-in ones with the same name and defining operations"`
+	// Plugins are the operations defined in the Templates directory to
+	// generate, with the types of their parameters by name.
+	Plugins map[string]map[string]string `no-flag:"true"`
+	Dir     string                       `long:"dir" description:"directory of the package to generate the code in, by default the one of --output or the current one"`
+	Output  string                       `short:"o" long:"output" description:"file to write the code to, - for stdout"`
+	Force   bool                         `long:"force" description:"overwrite the output file even if it was not generated by go-itergen" header:"-"`
+	Check   bool                         `long:"check" description:"write nothing and fail showing the differences if the generated files are not up to date" header:"-"`
 
 	Type        TypeDef
 	MapResults  []TypeDef
 	ReduceTypes []TypeDef
-}
 
-// TypeDef is a type definition, with name, package and type
-type TypeDef struct {
-	Name    string
-	Package string
-	Type    string
-	IsChan  bool
+	// inferredPackage is the package of the generated code when Package is
+	// not given.
+	inferredPackage string
+	// origin is the command that generated the code, if it was not
+	// generated just from the generator options.
+	origin string
+	// fileTypes are the types of all the iterables generated in the same
+	// file as this one.
+	fileTypes []TypeDef
+	// templates are the templates loaded from the Templates directory, by
+	// name.
+	templates map[string]*template.Template
+	// requested are the packages the templates referred to with the pkg and
+	// qualified functions while generating the code.
+	requested []Import
+	// pluginOps are the enabled plugins, with their parameters parsed.
+	pluginOps []pluginOp
+	// loader loads the packages the types refer to. It is shared by the
+	// generators of a batch.
+	loader *loader
+	// ignorePackageEnv is true when the iterable is generated along with the
+	// ones of other packages, so GOPACKAGE, which go generate sets for the
+	// package of the directive, does not apply to it.
+	ignorePackageEnv bool
 }
 
-type generatorFunc func(io.Writer) error
-
 func (g *Generator) parseTypes() error {
-	td, err := g.parseType(g.RawType)
-	if err != nil {
-		return err
+	var problems []string
+
+	g.Type = TypeDef{}
+	g.MapResults = nil
+	g.ReduceTypes = nil
+
+	if strings.TrimSpace(g.RawType) == "" {
+		problems = append(problems, "no type given")
+	} else if td, err := g.parseType(g.RawType); err != nil {
+		problems = append(problems, err.Error())
+	} else {
+		g.Type = td
+	}
+
+	if g.Name != "" {
+		if !token.IsIdentifier(g.Name) {
+			problems = append(problems, fmt.Sprintf("invalid iterable name given: %s, it must be a valid Go identifier", g.Name))
+		} else if g.Type.Type != "" {
+			g.Type.Name = g.Name
+		}
 	}
-	g.Type = td
 
 	for _, m := range g.Map {
-		td, err := g.parseType(m)
+		td, err := g.parseTarget(m)
 		if err != nil {
-			return err
+			problems = append(problems, fmt.Sprintf("--map %q: %s", m, err))
+			continue
 		}
 
 		g.MapResults = append(g.MapResults, td)
 	}
 
 	for _, r := range g.Reduce {
-		td, err := g.parseType(r)
+		td, err := g.parseTarget(r)
 		if err != nil {
-			return err
+			problems = append(problems, fmt.Sprintf("--reduce %q: %s", r, err))
+			continue
 		}
 
 		g.ReduceTypes = append(g.ReduceTypes, td)
 	}
 
-	return nil
+	return validationError(problems)
 }
 
 func (g *Generator) parseType(raw string) (TypeDef, error) {
-	var (
-		t   TypeDef
-		err error
-	)
-
-	t.Package, t.Type, t.IsChan, err = g.parseRawType(raw)
-	t.Name = g.getTypeName(t.Type)
-
-	return t, err
+	return parseTypeDef(raw)
 }
 
-func (g *Generator) parseRawType(raw string) (string, string, bool, error) {
-	var (
-		pkg    string
-		typ    string
-		isChan bool
-		err    error
-	)
-
-	typeParts := strings.Split(raw, ":")
-	if len(typeParts) == 1 {
-		typ, isChan, err = parseType(raw)
-	} else {
-		pkg = typeParts[0]
-		typ, isChan, err = parseType(typeParts[1])
+// parseTarget parses the type of a map or reduce target, which may be
+// prefixed by the name to use for it in the form "Name=type".
+func (g *Generator) parseTarget(raw string) (TypeDef, error) {
+	name, spec := splitTargetName(raw)
+	td, err := g.parseType(spec)
+	if err != nil {
+		return td, err
+	}
+
+	if name != "" {
+		td.Name = name
 	}
 
-	return pkg, typ, isChan, err
+	return td, nil
 }
 
-func (g *Generator) getTypeName(t string) string {
-	if strings.HasPrefix(t, "*") {
-		t = t[1:]
-	}
+func (g *Generator) generateHeader(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s\n//\n// %s\n\n", generatedHeader, g.command())
+	return err
+}
 
-	if strings.Contains(t, ".") {
-		tParts := strings.Split(t, ".")
-		return strings.Title(tParts[0]) + strings.Title(tParts[1])
+func (g *Generator) packageName() string {
+	if g.Package == "" {
+		return g.inferredPackage
 	}
-
-	return strings.Title(t)
+	return g.Package
 }
 
 func (g *Generator) generatePackage(w io.Writer) error {
-	pkg := fmt.Sprintf("package %s\n\n", g.Package)
+	pkg := fmt.Sprintf("package %s\n\n", g.packageName())
 	_, err := w.Write([]byte(pkg))
 	return err
 }
 
-func (g *Generator) generateImports(w io.Writer) error {
-	pkgs := map[string]struct{}{
-		"errors": struct{}{},
-	}
-
-	if g.Type.Package != "" {
-		pkgs[g.Type.Package] = struct{}{}
-	}
-
-	for _, mr := range g.MapResults {
-		if mr.Package != "" {
-			pkgs[mr.Package] = struct{}{}
-		}
-	}
-
-	if g.Type.IsChan && g.Concat {
-		pkgs["sync"] = struct{}{}
-	}
-
-	var packages []string
-	for pkg := range pkgs {
-		packages = append(packages, pkg)
-	}
-
+func (g *Generator) generateImports(w io.Writer, imports []Import) error {
 	tpl, err := g.getTpl(importsTpl)
 	if err != nil {
 		return err
 	}
 
-	sort.Strings(packages)
-	return tpl.Execute(w, packages)
+	return tpl.Execute(w, imports)
 }
 
 func (g *Generator) generateType(w io.Writer) error {
@@ -164,257 +172,256 @@ func (g *Generator) generateType(w io.Writer) error {
 	return tpl.Execute(w, g.Type)
 }
 
-func (g *Generator) generateSome(w io.Writer) error {
-	if g.Some {
-		if g.Type.IsChan {
-			return errors.New("chan type does not support some")
-		}
-
-		tpl, err := g.getTpl(someTpl)
-		if err != nil {
-			return err
-		}
-		return tpl.Execute(w, g.Type)
+// generateMap generates the Map operation, along with the conversions of its
+// results to the given types.
+func (g *Generator) generateMap(w io.Writer) error {
+	if err := g.executeTpl(mapTpl, g.Type, w); err != nil {
+		return err
 	}
-	return nil
-}
-
-func (g *Generator) generateAll(w io.Writer) error {
-	if g.All {
-		if g.Type.IsChan {
-			return errors.New("chan type does not support all")
-		}
 
-		tpl, err := g.getTpl(allTpl)
-		if err != nil {
-			return err
-		}
-		return tpl.Execute(w, g.Type)
+	data := struct {
+		Name    string
+		Results []TypeDef
+	}{
+		Name:    g.Type.Name,
+		Results: g.MapResults,
 	}
-	return nil
+
+	return g.executeTpl(mapResultsTpl, data, w)
 }
 
-func (g *Generator) generateFilter(w io.Writer) error {
-	if g.Filter {
-		tpl, err := g.getTpl(filterTpl)
-		if err != nil {
-			return err
-		}
-		return tpl.Execute(w, g.Type)
+func (g *Generator) generateReduces(w io.Writer) error {
+	data := struct {
+		Name     string
+		Type     string
+		Reducers []TypeDef
+	}{
+		Name:     g.Type.Name,
+		Type:     g.Type.Type,
+		Reducers: g.ReduceTypes,
 	}
-	return nil
+
+	return g.executeTpl(reduceTpl, data, w)
 }
 
-func (g *Generator) generateMap(w io.Writer) error {
-	if len(g.Map) > 0 {
-		tpl, err := g.getTpl(mapTpl)
-		if err != nil {
-			return err
-		}
-		return tpl.Execute(w, g.Type)
+// executeTpl writes the code of the template with the given name for the
+// iterable with the given data.
+func (g *Generator) executeTpl(name string, data interface{}, w io.Writer) error {
+	tpl, err := g.getTpl(name)
+	if err != nil {
+		return err
 	}
-	return nil
+	return tpl.Execute(w, data)
 }
 
-func (g *Generator) generateMapResults(w io.Writer) error {
-	if len(g.Map) > 0 {
-		data := struct {
-			Name    string
-			Results []TypeDef
-		}{
-			Name:    g.Type.Name,
-			Results: g.MapResults,
-		}
+func (g *Generator) getTpl(tpl string) (*template.Template, error) {
+	t, err := getTemplate(tpl, g.Type.IsChan, g.templates)
+	if err != nil {
+		return nil, err
+	}
 
-		tpl, err := g.getTpl(mapResultsTpl)
-		if err != nil {
-			return err
-		}
-		return tpl.Execute(w, data)
+	t, err = t.Clone()
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return t.Funcs(g.funcs()), nil
 }
 
-func (g *Generator) generateForEach(w io.Writer) error {
-	if g.ForEach {
-		tpl, err := g.getTpl(forEachTpl)
-		if err != nil {
-			return err
-		}
-		return tpl.Execute(w, g.Type)
+func (g *Generator) generateCode() ([]byte, error) {
+	g.requested = nil
+	body := bytes.NewBuffer(nil)
+	if err := g.generateBody(body); err != nil {
+		return nil, err
 	}
-	return nil
+
+	return g.generateFile(g.imports(), body.Bytes())
 }
 
-func (g *Generator) generateConcat(w io.Writer) error {
-	if g.Concat {
-		tpl, err := g.getTpl(concatTpl)
-		if err != nil {
-			return err
-		}
-		return tpl.Execute(w, g.Type)
+// generateFile returns the code of a file with the given body, importing the
+// ones of the given imports the body uses.
+func (g *Generator) generateFile(imports []Import, body []byte) ([]byte, error) {
+	code, err := g.fileCode(imports, body)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	used, err := usedImports(code, imports)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.fileCode(used, body)
 }
 
-func (g *Generator) generateFind(w io.Writer) error {
-	if g.Find {
-		if g.Type.IsChan {
-			return errors.New("chan iter does not support find")
-		}
+func (g *Generator) fileCode(imports []Import, body []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	if err := g.generateHeader(buf); err != nil {
+		return nil, err
+	}
 
-		tpl, err := g.getTpl(findTpl)
-		if err != nil {
-			return err
-		}
-		return tpl.Execute(w, g.Type)
+	if err := g.generatePackage(buf); err != nil {
+		return nil, err
+	}
+
+	if err := g.generateImports(buf, imports); err != nil {
+		return nil, err
 	}
-	return nil
+
+	buf.Write(body)
+	return buf.Bytes(), nil
 }
 
-func (g *Generator) generateReverse(w io.Writer) error {
-	if g.Reverse {
-		if g.Type.IsChan {
-			return errors.New("chan iter does not support reverse")
-		}
+// generateBody generates all the code of the iterable after the imports: its
+// type and the enabled operations, the built-in ones first.
+func (g *Generator) generateBody(w io.Writer) error {
+	if err := g.generateType(w); err != nil {
+		return err
+	}
 
-		tpl, err := g.getTpl(reverseTpl)
-		if err != nil {
-			return err
+	for _, op := range operations {
+		if op.IsBuiltin() && op.enabled(g) {
+			if err := op.generate(g, w); err != nil {
+				return err
+			}
 		}
-		return tpl.Execute(w, g.Type)
 	}
-	return nil
+
+	return g.generatePlugins(w)
 }
 
-func (g *Generator) generateSplice(w io.Writer) error {
-	if g.Splice {
-		if g.Type.IsChan {
-			return errors.New("a chan iter does not support splice")
-		}
+const (
+	fileTpl     = "%s_iter.go"
+	chanFileTpl = "%schan_iter.go"
+	stdout      = "-"
+)
 
-		tpl, err := g.getTpl(spliceTpl)
-		if err != nil {
-			return err
-		}
-		return tpl.Execute(w, g.Type)
+func (g *Generator) fileName() string {
+	tpl := fileTpl
+	if g.Type.IsChan {
+		tpl = chanFileTpl
 	}
-	return nil
-}
 
-func (g *Generator) generateReduces(w io.Writer) error {
-	if len(g.Reduce) > 0 {
-		data := struct {
-			Name     string
-			Type     string
-			Reducers []TypeDef
-		}{
-			Name:     g.Type.Name,
-			Type:     g.Type.Type,
-			Reducers: g.ReduceTypes,
-		}
+	name := g.Type.Name
+	if name == "" {
+		name = g.Type.Type
+	}
 
-		tpl, err := g.getTpl(reduceTpl)
-		if err != nil {
-			return err
-		}
-		return tpl.Execute(w, data)
+	return fmt.Sprintf(tpl, fileify(name))
+}
+
+func (g *Generator) dir() string {
+	switch {
+	case g.Dir != "":
+		return g.Dir
+	case g.Output != "" && g.Output != stdout:
+		return filepath.Dir(g.Output)
+	default:
+		return "."
 	}
+}
 
-	return nil
+// outputFile returns the file the code is written to, which is the one given
+// as output or a file named after the type in the package directory.
+func (g *Generator) outputFile() string {
+	if g.Output != "" {
+		return g.Output
+	}
+	return filepath.Join(g.dir(), g.fileName())
 }
 
-func (g *Generator) generateArray(w io.Writer) error {
-	if g.Array {
-		if !g.Type.IsChan {
-			return errors.New("array is not supported on non-channel types")
-		}
+// prepare parses and validates the options, resolving everything needed to
+// generate the code.
+func (g *Generator) prepare() error {
+	problems := problemsOf(g.parseTypes())
+	parsed := len(problems) == 0
 
-		tpl, err := g.getTpl(arrayTpl)
-		if err != nil {
-			return err
-		}
-		return tpl.Execute(w, g.Type)
+	if g.Type.Type != "" {
+		problems = append(problems, problemsOf(g.applyPreset())...)
+		problems = append(problems, g.checkOperations()...)
 	}
 
-	return nil
-}
+	templateProblems := g.loadTemplates()
+	parsed = parsed && len(templateProblems) == 0
+	problems = append(problems, templateProblems...)
 
-func (g *Generator) getTpl(tpl string) (*template.Template, error) {
-	return getTemplate(tpl, g.Type.IsChan)
-}
+	problems = append(problems, g.checkTargets()...)
+	if parsed {
+		problems = append(problems, checkImports(make(map[string]importUse), g, "")...)
+	}
 
-func (g *Generator) generateCode() ([]byte, error) {
-	generators := []generatorFunc{
-		g.generatePackage,
-		g.generateImports,
-		g.generateType,
-		g.generateMap,
-		g.generateMapResults,
-		g.generateFilter,
-		g.generateAll,
-		g.generateSome,
-		g.generateForEach,
-		g.generateConcat,
-		g.generateFind,
-		g.generateReverse,
-		g.generateSplice,
-		g.generateReduces,
-		g.generateArray,
+	if parsed {
+		problems = append(problems, problemsOf(g.checkIdentifiers())...)
 	}
 
-	buf := bytes.NewBuffer(nil)
-	for _, gen := range generators {
-		err := gen(buf)
+	if g.Package == "" {
+		pkg, err := inferPackage(g.dir(), !g.ignorePackageEnv)
 		if err != nil {
-			return nil, err
+			problems = append(problems, err.Error())
 		}
+		g.inferredPackage = pkg
 	}
 
-	return buf.Bytes(), nil
-}
+	if parsed {
+		problems = append(problems, problemsOf(g.resolveTypes(g.dir()))...)
+	}
 
-const (
-	fileTpl     = "%s_iter.go"
-	chanFileTpl = "%schan_iter.go"
-)
+	return validationError(problems)
+}
 
-func (g *Generator) fileName() string {
-	tpl := fileTpl
-	if g.Type.IsChan {
-		tpl = chanFileTpl
+// source returns the formatted generated code of a prepared generator.
+func (g *Generator) source() ([]byte, error) {
+	code, err := g.generateCode()
+	if err != nil {
+		return nil, err
 	}
 
-	file := fmt.Sprintf(tpl, fileify(g.Type.Type))
-	return filepath.Join(".", file)
+	return format.Source(code)
 }
 
-// Generate writes the generated code to the correspondant file and returns an error if something failed
-func (g *Generator) Generate() error {
-	err := g.parseTypes()
-	if err != nil {
-		return err
+// GenerateSource returns the formatted generated code along with the path of
+// the file it would be written to by Generate, which is "-" for stdout.
+func (g *Generator) GenerateSource() ([]byte, string, error) {
+	if err := g.prepare(); err != nil {
+		return nil, "", err
 	}
 
-	code, err := g.generateCode()
+	code, err := g.source()
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
-	code, err = format.Source(code)
+	return code, g.outputFile(), nil
+}
+
+// WriteTo writes the formatted generated code to w.
+func (g *Generator) WriteTo(w io.Writer) (int64, error) {
+	code, _, err := g.GenerateSource()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return write(g.fileName(), code)
+	n, err := w.Write(code)
+	return int64(n), err
 }
 
-func parseType(t string) (string, bool, error) {
-	if strings.Contains(t, "<-") {
-		return "", false, fmt.Errorf("invalid channel type given: %s", t)
+// Generate writes the generated code to the correspondant file and returns an error if something failed.
+// A file that was not generated by go-itergen is not overwritten unless Force is set.
+func (g *Generator) Generate() error {
+	code, file, err := g.GenerateSource()
+	if err != nil {
+		return atGenerateLine(err)
+	}
+
+	if g.Check {
+		return checkOutput(file, code)
+	}
+
+	if !g.Force && file != stdout {
+		if err := checkOverwrite(file); err != nil {
+			return err
+		}
 	}
 
-	tParts := strings.Split(t, " ")
-	return tParts[len(tParts)-1], strings.HasPrefix(t, "chan"), nil
+	return writeOutput(file, code)
 }
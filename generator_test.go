@@ -2,6 +2,9 @@ package generator
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	_ "github.com/erizocosmico/go-itergen/statik"
 	. "gopkg.in/check.v1"
@@ -65,6 +68,15 @@ func (s *GeneratorSuite) TestGenerateImports(c *C) {
 		TypeDef{Package: "github.com/foo/bar"},
 	}
 
+	g4 := &Generator{}
+	g4.Type = TypeDef{
+		Package: "github.com/foo/bar",
+		Imports: []Import{{Path: "github.com/foo/bar"}, {Path: "os"}},
+	}
+	g4.ReduceTypes = []TypeDef{
+		TypeDef{Package: "foo", Imports: []Import{{Path: "foo"}}},
+	}
+
 	tc := []struct {
 		g      *Generator
 		result string
@@ -72,11 +84,12 @@ func (s *GeneratorSuite) TestGenerateImports(c *C) {
 		{g1, generatedImport1},
 		{g2, generatedImport2},
 		{g3, generatedImport3},
+		{g4, generatedImport3},
 	}
 
 	for _, t := range tc {
 		buf := bytes.NewBuffer(nil)
-		c.Assert(t.g.generateImports(buf), IsNil)
+		c.Assert(t.g.generateImports(buf, t.g.imports()), IsNil)
 		c.Assert(buf.String(), Equals, t.result)
 	}
 }
@@ -91,21 +104,25 @@ func (s *GeneratorSuite) TestGenerateType(c *C) {
 	c.Assert(buf.String(), Equals, generatedType)
 }
 
-func (s *GeneratorSuite) TestGenerateMap(c *C) {
-	g := &Generator{
-		RawType: "float64",
-		Map: []string{
-			"int",
-			"string",
-		},
+func (s *GeneratorSuite) TestGenerateChanType(c *C) {
+	tcs := []struct {
+		raw    string
+		result string
+	}{
+		{"chan float64", generatedChanType},
+		{"<-chan float64", generatedRecvChanType},
+	}
+
+	for _, tc := range tcs {
+		g := &Generator{RawType: tc.raw}
+		c.Assert(g.parseTypes(), IsNil)
+		buf := bytes.NewBuffer(nil)
+		c.Assert(g.generateType(buf), IsNil)
+		c.Assert(buf.String(), Equals, tc.result)
 	}
-	g.parseTypes()
-	buf := bytes.NewBuffer(nil)
-	c.Assert(g.generateMap(buf), IsNil)
-	c.Assert(buf.String(), Equals, generatedMap)
 }
 
-func (s *GeneratorSuite) TestGenerateMapResults(c *C) {
+func (s *GeneratorSuite) TestGenerateMap(c *C) {
 	g := &Generator{
 		RawType: "float64",
 		Map: []string{
@@ -115,8 +132,8 @@ func (s *GeneratorSuite) TestGenerateMapResults(c *C) {
 	}
 	g.parseTypes()
 	buf := bytes.NewBuffer(nil)
-	c.Assert(g.generateMapResults(buf), IsNil)
-	c.Assert(buf.String(), Equals, generatedMapResults)
+	c.Assert(g.generateOp("map", buf), IsNil)
+	c.Assert(buf.String(), Equals, generatedMap+generatedMapResults)
 }
 
 func (s *GeneratorSuite) TestGenerateFilters(c *C) {
@@ -126,7 +143,7 @@ func (s *GeneratorSuite) TestGenerateFilters(c *C) {
 	}
 	g.parseTypes()
 	buf := bytes.NewBuffer(nil)
-	c.Assert(g.generateFilter(buf), IsNil)
+	c.Assert(g.generateOp("filter", buf), IsNil)
 	c.Assert(buf.String(), Equals, generatedFilter)
 }
 
@@ -137,7 +154,7 @@ func (s *GeneratorSuite) TestGenerateSome(c *C) {
 	}
 	g.parseTypes()
 	buf := bytes.NewBuffer(nil)
-	c.Assert(g.generateSome(buf), IsNil)
+	c.Assert(g.generateOp("some", buf), IsNil)
 	c.Assert(buf.String(), Equals, generatedSome)
 }
 
@@ -148,7 +165,7 @@ func (s *GeneratorSuite) TestGenerateAll(c *C) {
 	}
 	g.parseTypes()
 	buf := bytes.NewBuffer(nil)
-	c.Assert(g.generateAll(buf), IsNil)
+	c.Assert(g.generateOp("all", buf), IsNil)
 	c.Assert(buf.String(), Equals, generatedAll)
 }
 
@@ -159,7 +176,7 @@ func (s *GeneratorSuite) TestGenerateConcat(c *C) {
 	}
 	g.parseTypes()
 	buf := bytes.NewBuffer(nil)
-	c.Assert(g.generateConcat(buf), IsNil)
+	c.Assert(g.generateOp("concat", buf), IsNil)
 	c.Assert(buf.String(), Equals, generatedConcat)
 }
 
@@ -170,7 +187,7 @@ func (s *GeneratorSuite) TestGenerateFind(c *C) {
 	}
 	g.parseTypes()
 	buf := bytes.NewBuffer(nil)
-	c.Assert(g.generateFind(buf), IsNil)
+	c.Assert(g.generateOp("find", buf), IsNil)
 	c.Assert(buf.String(), Equals, generatedFind)
 }
 
@@ -181,7 +198,7 @@ func (s *GeneratorSuite) TestGenerateForEach(c *C) {
 	}
 	g.parseTypes()
 	buf := bytes.NewBuffer(nil)
-	c.Assert(g.generateForEach(buf), IsNil)
+	c.Assert(g.generateOp("foreach", buf), IsNil)
 	c.Assert(buf.String(), Equals, generatedForEach)
 }
 
@@ -192,7 +209,7 @@ func (s *GeneratorSuite) TestGenerateReverse(c *C) {
 	}
 	g.parseTypes()
 	buf := bytes.NewBuffer(nil)
-	c.Assert(g.generateReverse(buf), IsNil)
+	c.Assert(g.generateOp("reverse", buf), IsNil)
 	c.Assert(buf.String(), Equals, generatedReverse)
 }
 
@@ -203,7 +220,7 @@ func (s *GeneratorSuite) TestGenerateSplice(c *C) {
 	}
 	g.parseTypes()
 	buf := bytes.NewBuffer(nil)
-	c.Assert(g.generateSplice(buf), IsNil)
+	c.Assert(g.generateOp("splice", buf), IsNil)
 	c.Assert(buf.String(), Equals, generatedSplice)
 }
 
@@ -217,6 +234,108 @@ func (s *GeneratorSuite) TestGenerateReducers(c *C) {
 	}
 	g.parseTypes()
 	buf := bytes.NewBuffer(nil)
-	c.Assert(g.generateReduces(buf), IsNil)
+	c.Assert(g.generateOp("reduce", buf), IsNil)
 	c.Assert(buf.String(), Equals, generatedReducers)
 }
+
+func (s *GeneratorSuite) TestGenerateSource(c *C) {
+	g := New(Options{
+		Type:    "float64",
+		Package: "foo",
+		Some:    true,
+	})
+
+	code, file, err := g.GenerateSource()
+	c.Assert(err, IsNil)
+	c.Assert(file, Equals, "float64_iter.go")
+	c.Assert(string(code), Equals, generatedSource)
+
+	// generating again must give the same result
+	buf := bytes.NewBuffer(nil)
+	n, err := g.WriteTo(buf)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, int64(len(generatedSource)))
+	c.Assert(buf.String(), Equals, generatedSource)
+}
+
+func (s *GeneratorSuite) TestGenerateSourceErrors(c *C) {
+	_, _, err := New(Options{Type: "chan float64", Package: "foo", Some: true}).GenerateSource()
+	c.Assert(err, ErrorMatches, "--some is not supported by chan types, remove it or use a slice type")
+
+	_, _, err = New(Options{Type: "float64", Dir: c.MkDir()}).GenerateSource()
+	c.Assert(err, ErrorMatches, "cannot infer the package .*")
+}
+
+func (s *GeneratorSuite) TestGenerate(c *C) {
+	dir := c.MkDir()
+	opts := Options{Type: "float64", Package: "foo", Some: true, Dir: dir}
+	c.Assert(New(opts).Generate(), IsNil)
+
+	expected, file, err := New(opts).GenerateSource()
+	c.Assert(err, IsNil)
+	c.Assert(file, Equals, filepath.Join(dir, "float64_iter.go"))
+	c.Assert(string(expected), Matches, "(?s).*// go-itergen --type=float64 --pkg=foo --some\n.*")
+
+	code, err := ioutil.ReadFile(file)
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Equals, string(expected))
+
+	// generated files can be overwritten, hand-written files can not
+	c.Assert(New(opts).Generate(), IsNil)
+	c.Assert(ioutil.WriteFile(file, []byte("package foo\n"), 0644), IsNil)
+	c.Assert(New(opts).Generate(), ErrorMatches, "refusing to overwrite .*")
+
+	opts.Force = true
+	c.Assert(New(opts).Generate(), IsNil)
+	code, err = ioutil.ReadFile(file)
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Equals, string(expected))
+}
+
+func (s *GeneratorSuite) TestGenerateOutput(c *C) {
+	dir := c.MkDir()
+	output := filepath.Join(dir, "floats.go")
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "doc.go"), []byte("package foo\n"), 0644), IsNil)
+
+	g := New(Options{Type: "float64", Some: true, Output: output})
+	code, file, err := g.GenerateSource()
+	c.Assert(err, IsNil)
+	c.Assert(file, Equals, output)
+	c.Assert(string(code), Matches, "(?s).*// go-itergen --type=float64 --some --output=floats.go\n\npackage foo\n.*")
+
+	c.Assert(g.Generate(), IsNil)
+	written, err := ioutil.ReadFile(output)
+	c.Assert(err, IsNil)
+	c.Assert(string(written), Equals, string(code))
+}
+
+func (s *GeneratorSuite) TestGenerateCheck(c *C) {
+	dir := c.MkDir()
+	opts := Options{Type: "float64", Package: "foo", Some: true, Dir: dir, Check: true}
+	file := filepath.Join(dir, "float64_iter.go")
+
+	err := New(opts).Generate()
+	c.Assert(err, ErrorMatches, `(?s).*float64_iter.go is not up to date:
+--- .*float64_iter.go
+\+\+\+ .*float64_iter.go \(generated\)
+@@ -0,0 \+1,\d+ @@
+\+// Code generated by go-itergen. DO NOT EDIT.
+.*`)
+	_, err = os.Stat(file)
+	c.Assert(os.IsNotExist(err), Equals, true)
+
+	opts.Check = false
+	c.Assert(New(opts).Generate(), IsNil)
+
+	opts.Check = true
+	c.Assert(New(opts).Generate(), IsNil)
+
+	opts.Find = true
+	c.Assert(New(opts).Generate(), ErrorMatches, `(?s).*
+-// go-itergen --type=float64 --pkg=foo --some
+\+// go-itergen --type=float64 --pkg=foo --some --find
+.*\+func \(i Float64Iter\) Find.*`)
+
+	opts.Output = "-"
+	c.Assert(New(opts).Generate(), ErrorMatches, "--check can not be used when writing to stdout")
+}
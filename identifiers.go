@@ -0,0 +1,103 @@
+package generator
+
+import "fmt"
+
+// identifier is an identifier declared by the generated code, either at
+// package level or as a method of one of the generated types.
+type identifier struct {
+	// recv is the type the identifier is a method of, empty for package
+	// level identifiers.
+	recv string
+	name string
+	// origin is the option that caused the identifier to be generated.
+	origin string
+}
+
+func (i identifier) String() string {
+	if i.recv != "" {
+		return fmt.Sprintf("method %s.%s", i.recv, i.name)
+	}
+	return i.name
+}
+
+// identifiers returns all the identifiers the generated code will declare
+// for the current options. Types must have been parsed before.
+func (g *Generator) identifiers() []identifier {
+	var (
+		ids       []identifier
+		name      = g.Type.Name
+		iter      = name + "Iter"
+		result    = name + "IterMapResult"
+		errPrefix = "Err" + name + "To"
+		origin    = fmt.Sprintf("-t %q", g.RawType)
+	)
+
+	if g.Type.IsChan {
+		iter = name + "ChanIter"
+		result = name + "ChanMapResult"
+		errPrefix = "Err" + name + "ChanTo"
+	}
+
+	ids = append(ids, identifier{name: iter, origin: origin})
+	if !g.Type.IsChan {
+		ids = append(ids, identifier{name: "New" + iter, origin: origin})
+	}
+
+	if len(g.MapResults) > 0 {
+		ids = append(
+			ids,
+			identifier{name: result, origin: origin},
+			identifier{name: errPrefix + name, origin: origin},
+			identifier{recv: result, name: "Iter", origin: origin},
+		)
+	}
+
+	for i, r := range g.MapResults {
+		origin := fmt.Sprintf("--map %q", g.Map[i])
+		ids = append(
+			ids,
+			identifier{name: errPrefix + r.Name, origin: origin},
+			identifier{recv: result, name: "To" + r.Name, origin: origin},
+		)
+	}
+
+	for i, r := range g.ReduceTypes {
+		origin := fmt.Sprintf("--reduce %q", g.Reduce[i])
+		ids = append(ids, identifier{recv: iter, name: "Reduce" + r.Name, origin: origin})
+	}
+
+	return ids
+}
+
+// checkIdentifiers returns an error with a problem for every pair of options
+// generating the same identifier.
+func (g *Generator) checkIdentifiers() error {
+	var (
+		problems []string
+		seen     = make(map[identifier]identifier)
+		reported = make(map[[2]string]bool)
+	)
+
+	for _, id := range g.identifiers() {
+		key := identifier{recv: id.recv, name: id.name}
+		prev, ok := seen[key]
+		if !ok {
+			seen[key] = id
+			continue
+		}
+
+		pair := [2]string{id.origin, prev.origin}
+		if reported[pair] || id.origin == prev.origin {
+			// repeated options are reported by checkTargets
+			continue
+		}
+		reported[pair] = true
+
+		problems = append(problems, fmt.Sprintf(
+			"%s generated for %s collides with the one generated for %s, use --name or a Name=type target to rename one of them",
+			id, id.origin, prev.origin,
+		))
+	}
+
+	return validationError(problems)
+}
@@ -0,0 +1,74 @@
+package generator
+
+import . "gopkg.in/check.v1"
+
+type IdentifiersSuite struct{}
+
+var _ = Suite(&IdentifiersSuite{})
+
+func (s *IdentifiersSuite) TestIdentifiers(c *C) {
+	g := &Generator{
+		RawType: "float64",
+		Map:     []string{"int"},
+		Reduce:  []string{"Str=string"},
+	}
+	c.Assert(g.parseTypes(), IsNil)
+
+	var ids []string
+	for _, id := range g.identifiers() {
+		ids = append(ids, id.String())
+	}
+
+	c.Assert(ids, DeepEquals, []string{
+		"Float64Iter",
+		"NewFloat64Iter",
+		"Float64IterMapResult",
+		"ErrFloat64ToFloat64",
+		"method Float64IterMapResult.Iter",
+		"ErrFloat64ToInt",
+		"method Float64IterMapResult.ToInt",
+		"method Float64Iter.ReduceStr",
+	})
+}
+
+func (s *IdentifiersSuite) TestCheckIdentifiers(c *C) {
+	tcs := []struct {
+		g   *Generator
+		err string
+	}{
+		{
+			&Generator{RawType: "float64", Map: []string{"int", "Int"}},
+			`ErrFloat64ToInt generated for --map "Int" collides with the one generated for --map "int", .*`,
+		},
+		{
+			&Generator{RawType: "float64", Map: []string{"float64"}},
+			`ErrFloat64ToFloat64 generated for --map "float64" collides with the one generated for -t "float64", .*`,
+		},
+		{
+			&Generator{RawType: "foo:chan foo.Bar", Reduce: []string{"foo:foo.Bar", "FooBar=string"}},
+			`method FooBarChanIter.ReduceFooBar generated for --reduce "FooBar=string" collides with the one generated for --reduce "foo:foo.Bar", .*`,
+		},
+		{&Generator{RawType: "float64", Map: []string{"Float=float64"}}, ""},
+		{&Generator{RawType: "float64", Name: "Float", Map: []string{"float64"}}, ""},
+	}
+
+	for _, tc := range tcs {
+		c.Assert(tc.g.parseTypes(), IsNil)
+		err := tc.g.checkIdentifiers()
+		if tc.err == "" {
+			c.Assert(err, IsNil)
+		} else {
+			c.Assert(err, ErrorMatches, tc.err)
+		}
+	}
+}
+
+func (s *IdentifiersSuite) TestPrepareReportsCollisionsWithOtherProblems(c *C) {
+	g := New(Options{Type: "chan int", Some: true, Map: []string{"X=string", "X=int"}, Package: "foo"})
+	c.Assert(g.prepare(), ErrorMatches, `(?s)--some is not supported by chan types.*\nErrIntChanToX generated for --map "X=int" collides with the one generated for --map "X=string", .*`)
+}
+
+func (s *IdentifiersSuite) TestInvalidName(c *C) {
+	g := &Generator{RawType: "float64", Name: "Float 64"}
+	c.Assert(g.parseTypes(), ErrorMatches, "invalid iterable name given: Float 64, it must be a valid Go identifier")
+}
@@ -0,0 +1,284 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// name returns the name the package is referred to in the generated code,
+// which is its alias or, if there is none, the last element of its path.
+func (i Import) name() string {
+	if i.Alias != "" {
+		return i.Alias
+	}
+	return path.Base(i.Path)
+}
+
+// typeDefs returns all the parsed types the generated code refers to.
+func (g *Generator) typeDefs() []TypeDef {
+	defs := []TypeDef{g.Type}
+	defs = append(defs, g.MapResults...)
+	defs = append(defs, g.ReduceTypes...)
+	for _, op := range g.pluginOps {
+		for _, param := range op.op.Params {
+			defs = append(defs, op.params[param])
+		}
+	}
+	return defs
+}
+
+// typeImports returns the imports needed by the given type.
+func typeImports(t TypeDef) []Import {
+	imports := t.Imports
+	if t.Package == "" {
+		return imports
+	}
+
+	for _, imp := range imports {
+		if imp.Path == t.Package {
+			return imports
+		}
+	}
+
+	return append([]Import{{Path: t.Package}}, imports...)
+}
+
+// stdPackages returns the standard library packages the generated code uses.
+func (g *Generator) stdPackages() []string {
+	var ops []Operation
+	for _, op := range operations {
+		if op.IsBuiltin() && op.enabled(g) {
+			ops = append(ops, op)
+		}
+	}
+
+	for _, op := range g.pluginOps {
+		ops = append(ops, *op.op)
+	}
+
+	var pkgs []string
+	for _, op := range ops {
+		for _, imp := range op.imports(g.Type) {
+			if !containsString(pkgs, imp) {
+				pkgs = append(pkgs, imp)
+			}
+		}
+	}
+	return pkgs
+}
+
+// importName returns the name the generated code must use to refer to the
+// package with the given path. It is the name assumed from the path unless
+// that name is already used by one of the types in the file, in which case a
+// non-colliding alias is returned: the name prefixed with std for standard
+// library packages and suffixed with a number for the rest.
+func (g *Generator) importName(pkg string) string {
+	base := assumedName(pkg)
+	taken := make(map[string]bool)
+	for _, t := range append(g.typeDefs(), g.fileTypes...) {
+		for _, imp := range typeImports(t) {
+			if imp.Path == pkg && imp.name() == base {
+				return base
+			}
+			taken[imp.name()] = true
+		}
+
+		for _, q := range t.Qualifiers {
+			taken[q] = true
+		}
+	}
+
+	isStd := !strings.Contains(pkg, ".")
+	name := base
+	for i := 1; taken[name]; i++ {
+		if isStd {
+			name = "std" + base
+			if i > 1 {
+				name += strconv.Itoa(i)
+			}
+		} else {
+			name = base + strconv.Itoa(i+1)
+		}
+	}
+
+	return name
+}
+
+// assumedName returns the name of the package with the given import path as
+// goimports assumes it: the last element of the path, or the one before if
+// it is a major version like v2, without a go- prefix and up to the first
+// character that is not valid in an identifier.
+func assumedName(pkg string) string {
+	base := path.Base(pkg)
+	if len(base) > 1 && base[0] == 'v' {
+		if _, err := strconv.Atoi(base[1:]); err == nil && path.Dir(pkg) != "." {
+			base = path.Base(path.Dir(pkg))
+		}
+	}
+
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, notIdentifier); i >= 0 {
+		base = base[:i]
+	}
+
+	if base == "" || unicode.IsDigit(rune(base[0])) {
+		base = "pkg" + base
+	}
+	return base
+}
+
+func notIdentifier(r rune) bool {
+	return !(r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// funcs returns the functions of the templates that depend on the generator.
+// The packages they are given are recorded, so the generated code can import
+// them.
+func (g *Generator) funcs() template.FuncMap {
+	return template.FuncMap{
+		"pkg": func(pkg string) string {
+			name := g.importName(pkg)
+			g.request(pkg, name)
+			return name
+		},
+		"qualified": func(pkg, name string) string {
+			qualified := g.qualified(pkg, name)
+			if i := strings.LastIndex(qualified, "."); i >= 0 {
+				g.request(pkg, qualified[:i])
+			}
+			return qualified
+		},
+	}
+}
+
+// request records that the generated code refers to the package with the
+// given path by the given name.
+func (g *Generator) request(pkg, name string) {
+	imp := Import{Path: pkg}
+	if name != path.Base(pkg) {
+		imp.Alias = name
+	}
+
+	if !containsImport(g.requested, imp) {
+		g.requested = append(g.requested, imp)
+	}
+}
+
+func containsImport(imports []Import, imp Import) bool {
+	for _, i := range imports {
+		if i == imp {
+			return true
+		}
+	}
+	return false
+}
+
+// qualified returns the given identifier of the package with the given
+// import path qualified by the name the generated code uses for the package.
+// Identifiers of the package being generated, whose path is empty, are not
+// qualified.
+func (g *Generator) qualified(pkg, name string) string {
+	if pkg == "" {
+		return name
+	}
+
+	for _, t := range append(g.typeDefs(), g.fileTypes...) {
+		for _, imp := range typeImports(t) {
+			if imp.Path == pkg {
+				return imp.name() + "." + name
+			}
+		}
+	}
+
+	return g.importName(pkg) + "." + name
+}
+
+// imports returns all the imports the generated code may need sorted by
+// path, which are the ones of its types and their fields, the ones of the
+// enabled operations and the ones requested by the templates. Only the ones
+// the code uses are kept, see usedImports.
+func (g *Generator) imports() []Import {
+	var (
+		imports []Import
+		seen    = make(map[Import]bool)
+	)
+
+	add := func(imp Import) {
+		if !seen[imp] {
+			seen[imp] = true
+			imports = append(imports, imp)
+		}
+	}
+
+	for _, t := range g.typeDefs() {
+		for _, imp := range typeImports(t) {
+			add(imp)
+		}
+
+		for _, imp := range t.fieldImports {
+			add(imp)
+		}
+	}
+
+	for _, pkg := range g.stdPackages() {
+		imp := Import{Path: pkg}
+		if name := g.importName(pkg); name != pkg {
+			imp.Alias = name
+		}
+		add(imp)
+	}
+
+	for _, imp := range g.requested {
+		add(imp)
+	}
+
+	sortImports(imports)
+	return imports
+}
+
+// sortImports sorts the given imports by path and alias.
+func sortImports(imports []Import) {
+	sort.Slice(imports, func(i, j int) bool {
+		if imports[i].Path != imports[j].Path {
+			return imports[i].Path < imports[j].Path
+		}
+		return imports[i].Alias < imports[j].Alias
+	})
+}
+
+// usedImports returns the given imports whose names qualify an identifier in
+// the given code, like goimports does, so that no import is left unused.
+// Identifiers declared in the code, like the receivers of methods, are not
+// qualifiers even if they have the name of a package.
+func usedImports(code []byte, imports []Import) ([]Import, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", code, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+
+	var result []Import
+	for _, imp := range imports {
+		if used[imp.name()] {
+			result = append(result, imp)
+		}
+	}
+
+	return result, nil
+}
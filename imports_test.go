@@ -0,0 +1,227 @@
+package generator
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type ImportsSuite struct{}
+
+var _ = Suite(&ImportsSuite{})
+
+func (s *ImportsSuite) TestImportName(c *C) {
+	tcs := []struct {
+		g      *Generator
+		errors string
+		sync   string
+	}{
+		{&Generator{RawType: "float64"}, "errors", "sync"},
+		{&Generator{RawType: "errors:error", Map: []string{"sync:*sync.Mutex"}}, "errors", "sync"},
+		{&Generator{RawType: "github.com/foo/sync:sync.Mutex"}, "errors", "stdsync"},
+		{&Generator{RawType: "github.com/pkg/errors:*errors.Frame"}, "stderrors", "sync"},
+		{&Generator{RawType: "github.com/foo/go-errors as errors:errors.Err"}, "stderrors", "sync"},
+		{
+			&Generator{
+				RawType: "github.com/foo/go-errors as errors:errors.Err",
+				Map:     []string{"github.com/foo/stderrors:stderrors.Err"},
+			},
+			"stderrors2", "sync",
+		},
+		{&Generator{RawType: "errors as stderrors:stderrors.Err"}, "errors", "sync"},
+	}
+
+	for _, tc := range tcs {
+		c.Assert(tc.g.parseTypes(), IsNil)
+		c.Assert(tc.g.importName("errors"), Equals, tc.errors, Commentf(tc.g.RawType))
+		c.Assert(tc.g.importName("sync"), Equals, tc.sync, Commentf(tc.g.RawType))
+	}
+}
+
+func (s *ImportsSuite) TestAssumedName(c *C) {
+	for _, tc := range [][2]string{
+		{"sort", "sort"},
+		{"encoding/json", "json"},
+		{"github.com/foo/go-bar", "bar"},
+		{"github.com/foo/bar-go", "bar"},
+		{"github.com/foo/bar/v2", "bar"},
+		{"gopkg.in/yaml.v2", "yaml"},
+		{"github.com/foo/v2", "foo"},
+		{"github.com/foo/2d", "pkg2d"},
+	} {
+		c.Assert(assumedName(tc[0]), Equals, tc[1], Commentf(tc[0]))
+	}
+}
+
+func (s *ImportsSuite) TestImports(c *C) {
+	g := &Generator{
+		RawType: "github.com/foo/go-bar as bar, errors:chan bar.X[errors.Err]",
+		Map:     []string{"github.com/foo/go-bar as bar:bar.Y"},
+		Reduce:  []string{"github.com/foo/sync:sync.Z"},
+		Concat:  true,
+	}
+	c.Assert(g.parseTypes(), IsNil)
+	c.Assert(g.imports(), DeepEquals, []Import{
+		{Path: "errors"},
+		{Path: "github.com/foo/go-bar", Alias: "bar"},
+		{Path: "github.com/foo/sync"},
+		{Path: "sync", Alias: "stdsync"},
+	})
+}
+
+func (s *ImportsSuite) TestTemplatesUseImportName(c *C) {
+	g := &Generator{
+		RawType: "github.com/pkg/errors:errors.Frame",
+		Map:     []string{"int"},
+	}
+	c.Assert(g.parseTypes(), IsNil)
+
+	buf := bytes.NewBuffer(nil)
+	c.Assert(g.generateImports(buf, g.imports()), IsNil)
+	c.Assert(buf.String(), Equals, `import (
+  stderrors "errors"
+  "github.com/pkg/errors"
+)
+`)
+
+	buf.Reset()
+	c.Assert(g.generateOp("map", buf), IsNil)
+	c.Assert(strings.Contains(buf.String(), "= stderrors.New("), Equals, true)
+}
+
+func (s *ImportsSuite) TestUsedImports(c *C) {
+	code := []byte(`package foo
+
+import (
+	"errors"
+	"github.com/foo/bar"
+	baz "github.com/foo/go-baz"
+	"sort"
+	"sync"
+)
+
+type SyncIter []bar.X
+
+func (sync SyncIter) Sort() error {
+	sort.Slice(sync, func(i, j int) bool { return sync[i].Less(sync[j]) })
+	return nil
+}
+`)
+
+	imports := []Import{
+		{Path: "errors"},
+		{Path: "github.com/foo/bar"},
+		{Path: "github.com/foo/go-baz", Alias: "baz"},
+		{Path: "sort"},
+		{Path: "sync"},
+	}
+
+	used, err := usedImports(code, imports)
+	c.Assert(err, IsNil)
+	c.Assert(used, DeepEquals, []Import{{Path: "github.com/foo/bar"}, {Path: "sort"}})
+
+	_, err = usedImports([]byte("package foo\n\nfunc {"), imports)
+	c.Assert(err, ErrorMatches, "3:6: expected .*")
+}
+
+func (s *ImportsSuite) TestGeneratedCodeCompiles(c *C) {
+	dir := c.MkDir()
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "fields.tgo"), []byte(`---
+chan: true
+---
+func {{lowerFirst .Name}}FieldNames() []string {
+	return {{qualified "strings" "Fields"}}("{{range .Fields}}{{.Name}} {{end}}")
+}
+
+var _ = []func({{.Type}}) interface{}{
+	{{range .Fields}}func(x {{$.Type}}) interface{} { var v {{.Type}} = x.{{.Name}}; return v },
+	{{end}}
+}
+`), 0644), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "foo.go"), []byte(`package foo
+
+import "time"
+
+type Event struct {
+	At  time.Time
+	Err error
+}
+`), 0644), IsNil)
+
+	var gens []*Generator
+	for _, t := range []string{"float64", "Event", "chan Event", "time:chan time.Duration"} {
+		gens = append(gens,
+			New(Options{Type: t, Dir: dir}),
+			New(Options{Type: t, Dir: dir, Map: []string{"int", "time:time.Month"}}),
+			New(Options{Type: t, Dir: dir, Reduce: []string{"time:time.Duration"}}),
+			New(Options{Type: t, Dir: dir, Templates: dir, Plugins: map[string]map[string]string{"fields": nil}}),
+		)
+
+		isChan := strings.Contains(t, "chan ")
+		for _, op := range operations {
+			if op.flag != nil && op.supports(TypeDef{IsChan: isChan}) {
+				g := New(Options{Type: t, Dir: dir})
+				*op.flag(g) = true
+				gens = append(gens, g)
+			}
+		}
+	}
+
+	fset := token.NewFileSet()
+	local, err := parser.ParseFile(fset, "foo.go", mustRead(c, filepath.Join(dir, "foo.go")), 0)
+	c.Assert(err, IsNil)
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	for _, g := range gens {
+		code, _, err := g.GenerateSource()
+		c.Assert(err, IsNil)
+
+		f, err := parser.ParseFile(fset, "iter.go", code, 0)
+		c.Assert(err, IsNil)
+
+		_, err = conf.Check("foo", fset, []*ast.File{local, f}, nil)
+		c.Assert(err, IsNil, Commentf("%s", code))
+	}
+}
+
+func (s *ImportsSuite) TestPackageNamedUnlikeItsPath(c *C) {
+	dir := c.MkDir()
+	files := map[string]string{
+		"go.mod":        "module example.com/mod\n\ngo 1.16\n",
+		"go-bar/bar.go": "package bar\n\ntype X struct{}\n",
+		"use/use.go":    "package use\n",
+	}
+	for file, code := range files {
+		file = filepath.Join(dir, file)
+		c.Assert(os.MkdirAll(filepath.Dir(file), 0755), IsNil)
+		c.Assert(ioutil.WriteFile(file, []byte(code), 0644), IsNil)
+	}
+
+	use := filepath.Join(dir, "use")
+	g := New(Options{Type: "example.com/mod/go-bar:bar.X", Dir: use, Filter: true})
+	code, _, err := g.GenerateSource()
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Matches, `(?s).*\tbar "example.com/mod/go-bar"\n.*`)
+
+	l := newLoader()
+	f, err := parser.ParseFile(l.fset, filepath.Join(use, "iter.go"), code, 0)
+	c.Assert(err, IsNil)
+	conf := types.Config{Importer: l.importer(use)}
+	_, err = conf.Check("use", l.fset, []*ast.File{f}, nil)
+	c.Assert(err, IsNil, Commentf("%s", code))
+}
+
+func mustRead(c *C, file string) []byte {
+	b, err := ioutil.ReadFile(file)
+	c.Assert(err, IsNil)
+	return b
+}
@@ -0,0 +1,207 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// DefaultDirectivesFile is the file InitDirectives writes the go:generate
+// directives to.
+const DefaultDirectivesFile = "itergen.go"
+
+// SuggestConfig returns a config with an iterable for every named type
+// declared in the package in dir, with the operations suggested for its kind:
+//
+//   - channels get the pipeline preset on the channel type
+//   - numbers get filter, find, reverse, splice, all, some and a reduce to
+//     the type itself
+//   - other comparable types get filter, find, reverse and splice
+//   - structs get the functional preset
+//   - any other type gets filter and foreach
+//
+// Generic types and the files generated by go-itergen are skipped.
+func SuggestConfig(dir string) (*Config, error) {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		fset  = token.NewFileSet()
+		files []*ast.File
+	)
+
+	for _, name := range pkg.GoFiles {
+		file := filepath.Join(dir, name)
+		generated, err := isGenerated(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if generated {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// types are suggested even if the package does not build
+		Error: func(error) {},
+	}
+	checked, _ := conf.Check(absDir, fset, files, nil)
+
+	config := &Config{Package: pkg.Name, Iterables: []Options{}}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				if spec.TypeParams != nil || spec.Assign.IsValid() {
+					continue
+				}
+
+				obj, ok := checked.Scope().Lookup(spec.Name.Name).(*types.TypeName)
+				if !ok {
+					continue
+				}
+
+				if opts, ok := suggestOptions(obj); ok {
+					config.Iterables = append(config.Iterables, opts)
+				}
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// suggestOptions returns the options suggested for the iterable of the given
+// named type, if any.
+func suggestOptions(obj *types.TypeName) (Options, bool) {
+	var (
+		name  = obj.Name()
+		opts  = Options{Type: name}
+		under = obj.Type().Underlying()
+	)
+
+	if under == types.Typ[types.Invalid] {
+		return opts, false
+	}
+
+	qualifier := func(pkg *types.Package) string {
+		if pkg == obj.Pkg() {
+			return ""
+		}
+		return pkg.Name()
+	}
+
+	switch t := under.(type) {
+	case *types.Chan:
+		if t.Dir() == types.SendOnly {
+			return opts, false
+		}
+
+		opts.Type = types.TypeString(t, qualifier)
+		opts.Name = name
+		opts.Preset = "pipeline"
+	case *types.Struct:
+		opts.Preset = "functional"
+	default:
+		if !types.Comparable(under) {
+			opts.Filter, opts.ForEach = true, true
+			break
+		}
+
+		opts.Filter, opts.Find, opts.Reverse, opts.Splice = true, true, true, true
+		if basic, ok := under.(*types.Basic); ok && basic.Info()&types.IsNumeric != 0 {
+			opts.All, opts.Some = true, true
+			opts.Reduce = []string{name}
+		}
+	}
+
+	return opts, true
+}
+
+// InitConfig writes the config suggested for the package in dir to its
+// config file and returns the file. If force is false, an existing config
+// file is not overwritten.
+func InitConfig(dir string, force bool) (string, error) {
+	config, err := SuggestConfig(dir)
+	if err != nil {
+		return "", err
+	}
+
+	return WriteConfig(dir, config, force)
+}
+
+// WriteConfig writes the given config to the config file of the package in
+// dir and returns the file. If force is false, an existing config file is not
+// overwritten.
+func WriteConfig(dir string, config *Config, force bool) (string, error) {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	file := filepath.Join(dir, DefaultConfigFile)
+	return file, writeStarter(file, append(data, '\n'), force)
+}
+
+// InitDirectives writes a file with a go:generate directive for every
+// iterable suggested for the package in dir and returns the file. If force is
+// false, an existing file is not overwritten.
+func InitDirectives(dir string, force bool) (string, error) {
+	config, err := SuggestConfig(dir)
+	if err != nil {
+		return "", err
+	}
+
+	return WriteDirectives(dir, config, force)
+}
+
+// WriteDirectives writes a file with a go:generate directive for every
+// iterable of the given config in the package in dir and returns the file. If
+// force is false, an existing file is not overwritten.
+func WriteDirectives(dir string, config *Config, force bool) (string, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n\n", config.Package)
+	for _, opts := range config.Iterables {
+		fmt.Fprintln(&buf, New(opts).Directive())
+	}
+
+	file := filepath.Join(dir, DefaultDirectivesFile)
+	return file, writeStarter(file, buf.Bytes(), force)
+}
+
+func writeStarter(file string, content []byte, force bool) error {
+	if _, err := os.Stat(file); err == nil && !force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", file)
+	}
+
+	return ioutil.WriteFile(file, content, 0644)
+}
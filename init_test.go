@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type InitSuite struct{}
+
+var _ = Suite(&InitSuite{})
+
+const initSource = `package foo
+
+import "time"
+
+type Celsius float64
+
+type Name string
+
+type Order struct {
+	ID int
+}
+
+type Jobs chan time.Time
+
+type Orders <-chan Order
+
+type Sink chan<- int
+
+type Handler func()
+
+type Pair[K comparable] struct{}
+
+type Alias = int
+`
+
+func initPackage(c *C) string {
+	dir := c.MkDir()
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "foo.go"), []byte(initSource), 0644), IsNil)
+	generated := generatedHeader + "\n\npackage foo\n\ntype NameIter []Name\n"
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "name_iter.go"), []byte(generated), 0644), IsNil)
+	return dir
+}
+
+func (s *InitSuite) TestSuggestConfig(c *C) {
+	config, err := SuggestConfig(initPackage(c))
+	c.Assert(err, IsNil)
+	c.Assert(config, DeepEquals, &Config{
+		Package: "foo",
+		Iterables: []Options{
+			{Type: "Celsius", Filter: true, All: true, Some: true, Find: true, Reverse: true, Splice: true, Reduce: []string{"Celsius"}},
+			{Type: "Name", Filter: true, Find: true, Reverse: true, Splice: true},
+			{Type: "Order", Preset: "functional"},
+			{Type: "chan time.Time", Name: "Jobs", Preset: "pipeline"},
+			{Type: "<-chan Order", Name: "Orders", Preset: "pipeline"},
+			{Type: "Handler", Filter: true, ForEach: true},
+		},
+	})
+}
+
+func (s *InitSuite) TestInitConfig(c *C) {
+	dir := initPackage(c)
+	file, err := InitConfig(dir, false)
+	c.Assert(err, IsNil)
+	c.Assert(file, Equals, filepath.Join(dir, DefaultConfigFile))
+
+	config, err := LoadConfig(file)
+	c.Assert(err, IsNil)
+	c.Assert(config.Iterables, HasLen, 6)
+
+	_, err = InitConfig(dir, false)
+	c.Assert(err, ErrorMatches, ".*itergen.json already exists, use --force to overwrite it")
+
+	_, err = InitConfig(dir, true)
+	c.Assert(err, IsNil)
+}
+
+func (s *InitSuite) TestInitDirectives(c *C) {
+	dir := initPackage(c)
+	file, err := InitDirectives(dir, false)
+	c.Assert(err, IsNil)
+	c.Assert(file, Equals, filepath.Join(dir, DefaultDirectivesFile))
+
+	content, err := ioutil.ReadFile(file)
+	c.Assert(err, IsNil)
+	c.Assert(string(content), Equals, `package foo
+
+//go:generate go-itergen --type=Celsius --filter --all --some --find --reverse --splice --reduce=Celsius
+//go:generate go-itergen --type=Name --filter --find --reverse --splice
+//go:generate go-itergen --type=Order --preset=functional
+//go:generate go-itergen --type="chan time.Time" --name=Jobs --preset=pipeline
+//go:generate go-itergen --type="<-chan Order" --name=Orders --preset=pipeline
+//go:generate go-itergen --type=Handler --filter --foreach
+`)
+
+	_, err = InitDirectives(dir, false)
+	c.Assert(err, ErrorMatches, ".*itergen.go already exists, use --force to overwrite it")
+}
+
+func (s *InitSuite) TestWriteSuggested(c *C) {
+	dir := c.MkDir()
+	config := &Config{
+		Package:   "foo",
+		Iterables: []Options{{Type: "[]byte", Name: "Bytes", Filter: true}},
+	}
+
+	file, err := WriteConfig(dir, config, false)
+	c.Assert(err, IsNil)
+	loaded, err := LoadConfig(file)
+	c.Assert(err, IsNil)
+	c.Assert(loaded.Iterables, HasLen, 1)
+	c.Assert(loaded.Iterables[0].Name, Equals, "Bytes")
+
+	file, err = WriteDirectives(dir, config, false)
+	c.Assert(err, IsNil)
+	content, err := ioutil.ReadFile(file)
+	c.Assert(err, IsNil)
+	c.Assert(string(content), Equals, "package foo\n\n//go:generate go-itergen --type=\"[]byte\" --name=Bytes --filter\n")
+
+	_, err = WriteDirectives(dir, config, false)
+	c.Assert(err, ErrorMatches, ".*itergen.go already exists, use --force to overwrite it")
+}
@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// loader loads the packages referenced by the generated code from the export
+// data the go command builds for them, which is much faster than type
+// checking them from source. A loader is shared by all the generators of a
+// batch, so a package is only loaded once for all of them. It is not safe for
+// concurrent use.
+type loader struct {
+	fset *token.FileSet
+	// resolvers are the resolvers of every directory, by absolute path.
+	resolvers map[string]*resolver
+	// exports are the export data files of the packages listed from every
+	// directory, or the errors listing them, by directory and import path.
+	exports map[string]map[string]exportData
+}
+
+type exportData struct {
+	file string
+	err  error
+}
+
+func newLoader() *loader {
+	return &loader{
+		fset:      token.NewFileSet(),
+		resolvers: make(map[string]*resolver),
+		exports:   make(map[string]map[string]exportData),
+	}
+}
+
+// resolver returns the resolver of the package in the given directory.
+func (l *loader) resolver(dir string) (*resolver, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if r, ok := l.resolvers[dir]; ok {
+		return r, nil
+	}
+
+	r, err := newResolver(dir, l)
+	if err != nil {
+		return nil, err
+	}
+
+	l.resolvers[dir] = r
+	return r, nil
+}
+
+// importer returns an importer of the packages imported from the package in
+// dir, which resolves import paths as the go command does in dir.
+func (l *loader) importer(dir string) types.ImporterFrom {
+	lookup := func(path string) (io.ReadCloser, error) {
+		file, err := l.export(dir, path)
+		if err != nil {
+			return nil, err
+		}
+		return os.Open(file)
+	}
+
+	return importer.ForCompiler(l.fset, "gc", lookup).(types.ImporterFrom)
+}
+
+// listedPackage is the part of the output of go list -json used to find the
+// export data of a package.
+type listedPackage struct {
+	ImportPath string
+	Export     string
+	Error      *listError
+	DepsErrors []*listError
+}
+
+type listError struct {
+	Err string
+}
+
+// export returns the export data file of the package with the given import
+// path, as seen from dir, building it if needed.
+func (l *loader) export(dir, path string) (string, error) {
+	l.list(dir, path)
+	data := l.exports[dir][path]
+	return data.file, data.err
+}
+
+// list finds the export data files of the packages with the given import
+// paths, as seen from dir, that were not listed yet, with a single go list
+// call.
+func (l *loader) list(dir string, paths ...string) {
+	if l.exports[dir] == nil {
+		l.exports[dir] = make(map[string]exportData)
+	}
+
+	var missing []string
+	for _, path := range paths {
+		if _, ok := l.exports[dir][path]; !ok {
+			missing = append(missing, path)
+		}
+	}
+
+	if len(missing) == 0 {
+		return
+	}
+
+	pkgs, err := listPackages(dir, missing)
+	for _, path := range missing {
+		data := exportData{err: err}
+		if pkg, ok := pkgs[path]; ok {
+			data.file, data.err = pkg.export()
+		} else if err == nil {
+			data.err = fmt.Errorf("package %s was not listed", path)
+		}
+		l.exports[dir][path] = data
+	}
+}
+
+// listPackages runs go list in dir for the given import paths, building
+// their export data, and returns the listed packages by import path.
+func listPackages(dir string, paths []string) (map[string]listedPackage, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", append([]string{"list", "-e", "-export", "-json", "--"}, paths...)...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+
+	pkgs := make(map[string]listedPackage)
+	dec := json.NewDecoder(&stdout)
+	for dec.More() {
+		var pkg listedPackage
+		if err := dec.Decode(&pkg); err != nil {
+			return nil, err
+		}
+		pkgs[pkg.ImportPath] = pkg
+	}
+
+	return pkgs, nil
+}
+
+// export returns the export data file of the listed package, or the error
+// listing or building it.
+func (p listedPackage) export() (string, error) {
+	switch {
+	case p.Error != nil:
+		return "", errors.New(strings.TrimSpace(p.Error.Err))
+	case len(p.DepsErrors) > 0:
+		return "", errors.New(strings.TrimSpace(p.DepsErrors[0].Err))
+	case p.Export == "":
+		return "", fmt.Errorf("no export data for package %s", p.ImportPath)
+	}
+
+	return p.Export, nil
+}
+
+// shareLoader makes all the given generators without a loader share a new
+// one.
+func shareLoader(gens []*Generator) {
+	l := newLoader()
+	for _, g := range gens {
+		if g.loader == nil {
+			g.loader = l
+		}
+	}
+}
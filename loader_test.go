@@ -0,0 +1,52 @@
+package generator
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type LoaderSuite struct{}
+
+var _ = Suite(&LoaderSuite{})
+
+func (s *LoaderSuite) TestResolver(c *C) {
+	dir := c.MkDir()
+	l := newLoader()
+
+	r, err := l.resolver(dir)
+	c.Assert(err, IsNil)
+
+	time, err := r.load("time")
+	c.Assert(err, IsNil)
+	c.Assert(time.Scope().Lookup("Duration"), NotNil)
+
+	other, err := l.resolver(dir + "/.")
+	c.Assert(err, IsNil)
+	c.Assert(other, Equals, r)
+}
+
+func (s *LoaderSuite) TestExport(c *C) {
+	dir := c.MkDir()
+	l := newLoader()
+
+	file, err := l.export(dir, "time")
+	c.Assert(err, IsNil)
+	c.Assert(file, Not(Equals), "")
+
+	_, err = l.export(dir, "nope/nope")
+	c.Assert(err, ErrorMatches, `.*package nope/nope is not in std.*`)
+
+	l.list(dir, "io", "strings", "nope/other")
+	c.Assert(l.exports[dir]["io"].file, Not(Equals), "")
+	c.Assert(l.exports[dir]["strings"].file, Not(Equals), "")
+	c.Assert(l.exports[dir]["nope/other"].err, NotNil)
+}
+
+func (s *LoaderSuite) TestShareLoader(c *C) {
+	l := newLoader()
+	gens := []*Generator{{}, {loader: l}, {}}
+	shareLoader(gens)
+
+	c.Assert(gens[0].loader, NotNil)
+	c.Assert(gens[0].loader, Equals, gens[2].loader)
+	c.Assert(gens[1].loader, Equals, l)
+}
@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// Operation describes an operation that can be generated for iterables.
+type Operation struct {
+	// Name is the name of the operation in flags, config files and
+	// annotations.
+	Name string
+	// Description is a short description of the generated code.
+	Description string
+	// Slice and Chan report whether the operation can be generated for slice
+	// and channel iterables respectively.
+	Slice bool
+	Chan  bool
+	// Params are the names of the types the operation needs, given with
+	// --name-param.
+	Params []string
+	// Imports and ChanImports are the import paths of the packages used by
+	// the code generated for slice and channel iterables respectively.
+	Imports     []string
+	ChanImports []string
+	// Template and ChanTemplate are the text/template sources of the code
+	// generated for slice and channel iterables. They are executed with the
+	// TypeDef of the iterable, along with the types of the parameters in
+	// Params by name, and must refer to the imported packages with the pkg
+	// function. They are empty for the built-in operations, whose templates
+	// are bundled in go-itergen.
+	Template     string
+	ChanTemplate string
+
+	// flag returns the option enabling a built-in boolean operation.
+	flag func(*Generator) *bool
+	// enabled reports whether a built-in operation is enabled for the
+	// iterable of the generator.
+	enabled func(*Generator) bool
+	// generate writes the code of a built-in operation.
+	generate func(*Generator, io.Writer) error
+
+	tpl, chanTpl *template.Template
+}
+
+// operations are all the registered operations, the built-in ones first, in
+// the order their code is generated.
+var operations = []Operation{
+	{
+		Name:        "map",
+		Description: "apply a function to every element and convert the results to the given types",
+		Slice:       true,
+		Chan:        true,
+		Imports:     []string{"errors"},
+		ChanImports: []string{"errors"},
+		enabled:     func(g *Generator) bool { return len(g.Map) > 0 },
+		generate:    (*Generator).generateMap,
+	},
+	flagOperation("filter", "keep the elements for which a function returns true", true, true),
+	flagOperation("all", "report whether a function returns true for all the elements", true, false),
+	flagOperation("some", "report whether a function returns true for any of the elements", true, false),
+	flagOperation("foreach", "call a function for every element", true, true),
+	flagOperation("concat", "concatenate slices or multiplex channels", true, true, "sync"),
+	flagOperation("find", "return the first element for which a function returns true", true, false),
+	flagOperation("reverse", "return the elements in reverse order", true, false),
+	flagOperation("splice", "remove a number of elements after the given start", true, false),
+	{
+		Name:        "reduce",
+		Description: "reduce the elements to a value of the given types",
+		Slice:       true,
+		Chan:        true,
+		enabled:     func(g *Generator) bool { return len(g.Reduce) > 0 },
+		generate:    (*Generator).generateReduces,
+	},
+	flagOperation("array", "collect the elements of a channel into a slice", false, true, "sync"),
+}
+
+// flagOperation returns a built-in operation enabled by the boolean option
+// named after it, whose template has the name of the operation and is
+// executed with the type of the iterable. chanImports are the imports of its
+// code for channel iterables.
+func flagOperation(name, description string, supportsSlice, supportsChan bool, chanImports ...string) Operation {
+	flag := func(g *Generator) *bool { return boolOption(g, "long", name) }
+	return Operation{
+		Name:        name,
+		Description: description,
+		Slice:       supportsSlice,
+		Chan:        supportsChan,
+		ChanImports: chanImports,
+		flag:        flag,
+		enabled:     func(g *Generator) bool { return *flag(g) },
+		generate: func(g *Generator, w io.Writer) error {
+			return g.executeTpl(name, g.Type, w)
+		},
+	}
+}
+
+// supports reports whether the operation can be generated for the given
+// type.
+func (o Operation) supports(t TypeDef) bool {
+	if t.IsChan {
+		return o.Chan
+	}
+	return o.Slice
+}
+
+// IsBuiltin reports whether the operation is one of the built-in ones, which
+// are enabled with the options of the Generator instead of its Plugins.
+func (o Operation) IsBuiltin() bool {
+	return o.generate != nil
+}
+
+// imports returns the imports of the code generated for the given type.
+func (o Operation) imports(t TypeDef) []string {
+	if t.IsChan {
+		return o.ChanImports
+	}
+	return o.Imports
+}
+
+// template returns the parsed template of an operation that is not built in
+// for the given type.
+func (o Operation) template(t TypeDef) *template.Template {
+	if t.IsChan {
+		return o.chanTpl
+	}
+	return o.tpl
+}
+
+// Operations returns all the operations that can be generated, in the order
+// their code is generated.
+func Operations() []Operation {
+	return append([]Operation(nil), operations...)
+}
+
+// RegisterOperation adds an operation that can be generated for iterables,
+// enabled with the Plugins of the Generator like the ones defined in
+// templates. ChanTemplate defaults to Template. It must be called before
+// generating any code, usually in an init function, and it is not safe to
+// call concurrently with the generation.
+func RegisterOperation(op Operation) error {
+	if op.ChanTemplate == "" {
+		op.ChanTemplate = op.Template
+	}
+
+	op.flag, op.enabled, op.generate = nil, nil, nil
+	if err := op.validate(); err != nil {
+		return err
+	}
+
+	for _, t := range []struct {
+		supported bool
+		text      string
+		tpl       **template.Template
+	}{{op.Slice, op.Template, &op.tpl}, {op.Chan, op.ChanTemplate, &op.chanTpl}} {
+		if !t.supported {
+			continue
+		}
+
+		if t.text == "" {
+			return fmt.Errorf("operation %s has no template", op.Name)
+		}
+
+		tpl, err := template.New(op.Name).Funcs(funcs).Parse(t.text)
+		if err != nil {
+			return err
+		}
+		*t.tpl = tpl
+	}
+
+	operations = append(operations, op)
+	return nil
+}
+
+// registeredOperation returns the registered operation with the given name
+// that is not built in, if any.
+func registeredOperation(name string) (*Operation, bool) {
+	for i, op := range operations {
+		if op.Name == name && !op.IsBuiltin() {
+			return &operations[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	. "gopkg.in/check.v1"
+)
+
+type OperationsSuite struct {
+	builtin []Operation
+}
+
+var _ = Suite(&OperationsSuite{})
+
+func (s *OperationsSuite) SetUpTest(c *C) {
+	s.builtin = operations
+}
+
+func (s *OperationsSuite) TearDownTest(c *C) {
+	operations = s.builtin
+}
+
+const windowTemplate = `
+func (i {{.Name}}Iter) Window(size int) []{{.Name}}Iter {
+  var result []{{.Name}}Iter
+  for n := 0; n+size <= len(i); n++ {
+    result = append(result, i[n:n+size])
+  }
+  {{pkg "sort"}}.Ints(nil)
+  return result
+}
+`
+
+func (s *OperationsSuite) TestBuiltinOperations(c *C) {
+	for _, op := range Operations() {
+		c.Assert(op.IsBuiltin(), Equals, true, Commentf("operation %s", op.Name))
+	}
+
+	g := &Generator{RawType: "chan float64", Filter: true, Concat: true, Map: []string{"int"}}
+	c.Assert(g.parseTypes(), IsNil)
+	c.Assert(g.stdPackages(), DeepEquals, []string{"errors", "sync"})
+
+	g = &Generator{RawType: "float64", Filter: true, Concat: true}
+	c.Assert(g.parseTypes(), IsNil)
+	c.Assert(g.stdPackages(), HasLen, 0)
+
+	c.Assert(g.generateOp("window", new(bytes.Buffer)), ErrorMatches, "operation window not found")
+}
+
+func (s *OperationsSuite) TestFlagOperationOptions(c *C) {
+	for _, op := range operations {
+		if op.flag == nil {
+			continue
+		}
+
+		var opts Options
+		*opts.flag(op.Name) = true
+		c.Assert(*op.flag(New(opts)), Equals, true, Commentf("operation %s", op.Name))
+	}
+}
+
+func (s *OperationsSuite) TestRegisterOperation(c *C) {
+	err := RegisterOperation(Operation{
+		Name:        "window",
+		Description: "return the windows of the given size",
+		Slice:       true,
+		Imports:     []string{"sort"},
+		Template:    windowTemplate,
+	})
+	c.Assert(err, IsNil)
+
+	ops := Operations()
+	c.Assert(ops, HasLen, len(s.builtin)+1)
+	window := ops[len(ops)-1]
+	c.Assert(window.Name, Equals, "window")
+	c.Assert(window.IsBuiltin(), Equals, false)
+
+	g := New(Options{
+		Type:    "float64",
+		Package: "foo",
+		Plugins: map[string]map[string]string{"window": nil},
+	})
+	code, _, err := g.GenerateSource()
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Matches, `(?s).*// go-itergen --type=float64 --pkg=foo --window\n.*import \(\n\t"sort"\n\).*func \(i Float64Iter\) Window\(size int\) \[\]Float64Iter \{.*`)
+
+	g = New(Options{
+		Type:    "chan float64",
+		Package: "foo",
+		Plugins: map[string]map[string]string{"window": nil},
+	})
+	_, _, err = g.GenerateSource()
+	c.Assert(err, ErrorMatches, "--window is not supported by chan types, remove it or use a slice type")
+}
+
+func (s *OperationsSuite) TestRegisterOperationErrors(c *C) {
+	cases := []struct {
+		op  Operation
+		err string
+	}{
+		{Operation{Name: "Window", Slice: true, Template: "x"}, `invalid operation name "Window", it must be lowercase words separated by dashes`},
+		{Operation{Name: "filter", Slice: true, Template: "x"}, "operation filter clashes with the option --filter"},
+		{Operation{Name: "output", Slice: true, Template: "x"}, "operation output clashes with the option --output"},
+		{Operation{Name: "window", Template: "x"}, "operation window supports neither slice nor chan types"},
+		{Operation{Name: "window", Slice: true}, "operation window has no template"},
+		{Operation{Name: "window", Slice: true, Template: "{{.Name"}, "template: window:1: unclosed action"},
+		{Operation{Name: "window", Slice: true, Template: "x", Params: []string{"size", "size"}}, "parameter size is declared more than once"},
+	}
+
+	for _, tt := range cases {
+		c.Assert(RegisterOperation(tt.op), ErrorMatches, tt.err)
+	}
+
+	c.Assert(Operations(), HasLen, len(s.builtin))
+
+	c.Assert(RegisterOperation(Operation{Name: "window", Chan: true, ChanTemplate: "x"}), IsNil)
+	c.Assert(RegisterOperation(Operation{Name: "window", Slice: true, Template: "x"}), ErrorMatches, "operation window clashes with the option --window")
+}
+
+// generateOp writes the code of the built-in operation with the given name.
+func (g *Generator) generateOp(name string, w io.Writer) error {
+	for _, op := range operations {
+		if op.Name == name && op.IsBuiltin() {
+			return op.generate(g, w)
+		}
+	}
+	return fmt.Errorf("operation %s not found", name)
+}
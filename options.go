@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Options are the options to generate the code of an iterable type.
+type Options struct {
+	// Type is the type to generate the code for, in the form
+	// "[import/path:]type".
+	Type string `json:"type"`
+	// Package is the package of the generated code. If empty, it is inferred
+	// from $GOPACKAGE or the files in Dir.
+	Package string `json:"package,omitempty"`
+	// Name is the name of the iterable. If empty, it is derived from Type.
+	Name string `json:"name,omitempty"`
+	// Dir is the directory of the package the code is generated for. If
+	// empty, the directory of Output or the current directory is used.
+	Dir string `json:"-"`
+	// Output is the file Generate writes the code to, "-" being stdout. If
+	// empty, a file named after the type is created in Dir.
+	Output string `json:"output,omitempty"`
+	// Force makes Generate overwrite the output file even if it was not
+	// generated by go-itergen.
+	Force bool `json:"-"`
+	// Check makes Generate fail with the differences between the code and
+	// the output file instead of writing it.
+	Check bool `json:"-"`
+
+	// Map are the types to generate Map conversions for, optionally named
+	// with "Name=type".
+	Map []string `json:"map,omitempty"`
+	// Reduce are the types to generate Reduce functions for, optionally
+	// named with "Name=type".
+	Reduce []string `json:"reduce,omitempty"`
+
+	Filter  bool `json:"filter,omitempty"`
+	All     bool `json:"all,omitempty"`
+	Some    bool `json:"some,omitempty"`
+	ForEach bool `json:"foreach,omitempty"`
+	Concat  bool `json:"concat,omitempty"`
+	Find    bool `json:"find,omitempty"`
+	Reverse bool `json:"reverse,omitempty"`
+	Splice  bool `json:"splice,omitempty"`
+	Array   bool `json:"array,omitempty"`
+
+	// Preset enables the operations of the preset with the given name that
+	// are supported by the iterable: all, functional or pipeline.
+	Preset string `json:"preset,omitempty"`
+	// AllOps enables all the operations supported by the iterable.
+	AllOps bool `json:"all-ops,omitempty"`
+	// Templates is a directory with *.tgo templates that override the
+	// built-in ones with the same name.
+	Templates string `json:"templates,omitempty"`
+	// Plugins are the operations defined in the Templates directory to
+	// generate, with the types of their parameters by name.
+	Plugins map[string]map[string]string `json:"plugins,omitempty"`
+}
+
+// New returns a new Generator with the given options.
+func New(opts Options) *Generator {
+	g := &Generator{
+		RawType:   opts.Type,
+		Package:   opts.Package,
+		Name:      opts.Name,
+		Dir:       opts.Dir,
+		Output:    opts.Output,
+		Force:     opts.Force,
+		Check:     opts.Check,
+		Map:       opts.Map,
+		Reduce:    opts.Reduce,
+		Preset:    opts.Preset,
+		AllOps:    opts.AllOps,
+		Templates: opts.Templates,
+		Plugins:   opts.Plugins,
+	}
+
+	for _, op := range operations {
+		if op.flag != nil {
+			*op.flag(g) = *opts.flag(op.Name)
+		}
+	}
+
+	return g
+}
+
+// flag returns the option enabling the built-in boolean operation with the
+// given name.
+func (o *Options) flag(name string) *bool {
+	return boolOption(o, "json", name)
+}
+
+// boolOption returns the boolean field of the struct v points to whose tag
+// with the given key names the option with the given name, which must exist.
+func boolOption(v interface{}, key, name string) *bool {
+	s := reflect.ValueOf(v).Elem()
+	for i := 0; i < s.NumField(); i++ {
+		tag := strings.Split(s.Type().Field(i).Tag.Get(key), ",")[0]
+		if tag == name && s.Field(i).Kind() == reflect.Bool {
+			return s.Field(i).Addr().Interface().(*bool)
+		}
+	}
+
+	panic(fmt.Sprintf("%T has no boolean option %s", v, name))
+}
@@ -0,0 +1,40 @@
+package generator
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+)
+
+// inferPackage returns the name of the package the code generated in dir
+// belongs to. It is taken from the GOPACKAGE environment variable, set by go
+// generate, unless useEnv is false, and from the Go files already in dir. It
+// is an error if none of them give a package name or if they give different
+// ones.
+func inferPackage(dir string, useEnv bool) (string, error) {
+	var env string
+	if useEnv {
+		env = os.Getenv("GOPACKAGE")
+	}
+
+	var files string
+	if _, err := os.Stat(dir); err == nil {
+		pkg, err := build.ImportDir(dir, 0)
+		if err == nil {
+			files = pkg.Name
+		} else if _, ok := err.(*build.NoGoError); !ok {
+			return "", fmt.Errorf("cannot infer package from the files in %s: %s", dir, err)
+		}
+	}
+
+	switch {
+	case env != "" && files != "" && env != files:
+		return "", fmt.Errorf("GOPACKAGE is %s but the files in %s belong to package %s, use --pkg to choose one", env, dir, files)
+	case env != "":
+		return env, nil
+	case files != "":
+		return files, nil
+	default:
+		return "", fmt.Errorf("cannot infer the package of the generated code, use --pkg to give it")
+	}
+}
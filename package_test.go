@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type PackageSuite struct {
+	env string
+}
+
+var _ = Suite(&PackageSuite{})
+
+func (s *PackageSuite) SetUpTest(c *C) {
+	s.env = os.Getenv("GOPACKAGE")
+}
+
+func (s *PackageSuite) TearDownTest(c *C) {
+	c.Assert(os.Setenv("GOPACKAGE", s.env), IsNil)
+}
+
+func (s *PackageSuite) TestInferPackage(c *C) {
+	empty := c.MkDir()
+	withFiles := c.MkDir()
+	file := filepath.Join(withFiles, "foo.go")
+	c.Assert(ioutil.WriteFile(file, []byte("package foo\n"), 0644), IsNil)
+
+	tcs := []struct {
+		env string
+		dir string
+		pkg string
+		err string
+	}{
+		{"foo", empty, "foo", ""},
+		{"", withFiles, "foo", ""},
+		{"foo", withFiles, "foo", ""},
+		{"foo", filepath.Join(empty, "missing"), "foo", ""},
+		{"bar", withFiles, "", "GOPACKAGE is bar but the files in .* belong to package foo, use --pkg to choose one"},
+		{"", empty, "", "cannot infer the package of the generated code, use --pkg to give it"},
+	}
+
+	for _, tc := range tcs {
+		c.Assert(os.Setenv("GOPACKAGE", tc.env), IsNil)
+		pkg, err := inferPackage(tc.dir, true)
+		if tc.err != "" {
+			c.Assert(err, ErrorMatches, tc.err)
+		} else {
+			c.Assert(err, IsNil)
+			c.Assert(pkg, Equals, tc.pkg)
+		}
+	}
+}
+
+func (s *PackageSuite) TestInferPackageIgnoringEnv(c *C) {
+	dir := c.MkDir()
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "foo.go"), []byte("package foo\n"), 0644), IsNil)
+	c.Assert(os.Setenv("GOPACKAGE", "bar"), IsNil)
+
+	pkg, err := inferPackage(dir, false)
+	c.Assert(err, IsNil)
+	c.Assert(pkg, Equals, "foo")
+
+	_, err = inferPackage(c.MkDir(), false)
+	c.Assert(err, ErrorMatches, "cannot infer the package of the generated code, use --pkg to give it")
+}
@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// PackageResult is the result of generating the iterables of a package.
+type PackageResult struct {
+	// Dir is the directory of the package.
+	Dir string
+	// Files are the files with the iterables of the package.
+	Files []string
+	// Err is the error generating the iterables, if any.
+	Err error
+}
+
+// GeneratePackages generates in parallel the iterables of the packages
+// matched by the given patterns, which are directories or, when ending in
+// "/...", directory trees. The iterables of a package are the ones in its
+// config file and the ones of its annotated types. Packages in directory
+// trees without any are skipped. A package failing does not stop the others,
+// the result of every package is returned. If force is true, files not
+// generated by go-itergen are overwritten.
+func GeneratePackages(patterns []string, force bool) ([]PackageResult, error) {
+	return generatePackages(patterns, force, false)
+}
+
+// CheckPackages is like GeneratePackages, but it checks that the generated
+// files of the packages are up to date instead of writing them.
+func CheckPackages(patterns []string) ([]PackageResult, error) {
+	return generatePackages(patterns, false, true)
+}
+
+func generatePackages(patterns []string, force, check bool) ([]PackageResult, error) {
+	dirs, err := matchPackages(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results = make([]PackageResult, len(dirs))
+		jobs    = make(chan int)
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results[j] = generatePackage(dirs[j], force, check, nil)
+			}
+		}()
+	}
+
+	for i := range dirs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+// generatePackage generates or checks the iterables of the config file and
+// the annotated types of the package in dir. If regenerate is not nil, only
+// the iterables it returns true for are generated, see generateSome.
+func generatePackage(dir string, force, check bool, regenerate func(*Generator) bool) PackageResult {
+	var (
+		result = PackageResult{Dir: dir}
+		errs   []error
+	)
+
+	config := filepath.Join(dir, DefaultConfigFile)
+	if _, err := os.Stat(config); err == nil {
+		files, err := generateConfig(config, force, check, true, regenerate)
+		result.Files = append(result.Files, files...)
+		errs = append(errs, err)
+	}
+
+	files, err := generateAnnotations(dir, force, check, regenerate)
+	result.Files = append(result.Files, files...)
+	result.Err = joinErrors(append(errs, err)...)
+
+	return result
+}
+
+// matchPackages returns the directories of the packages matched by the given
+// patterns. Directories in the trees matched by patterns ending in "/..."
+// are only returned if they have iterables to generate or files generated
+// from annotations, and vendor, testdata and nested module directories are
+// skipped, as well as the ones starting with "." or "_".
+func matchPackages(patterns []string) ([]string, error) {
+	var (
+		dirs []string
+		seen = make(map[string]bool)
+	)
+
+	add := func(dir string) {
+		if !seen[filepath.Clean(dir)] {
+			seen[filepath.Clean(dir)] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, pattern := range patterns {
+		if pattern != "..." && !strings.HasSuffix(pattern, "/...") {
+			info, err := os.Stat(pattern)
+			if err != nil {
+				return nil, err
+			}
+
+			if !info.IsDir() {
+				return nil, fmt.Errorf("%s is not a package directory", pattern)
+			}
+
+			add(pattern)
+			continue
+		}
+
+		root := strings.TrimSuffix(strings.TrimSuffix(pattern, "..."), "/")
+		if root == "" {
+			root = "."
+		}
+
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil || !info.IsDir() {
+				return err
+			}
+
+			if path != root && skipDir(path, info.Name()) {
+				return filepath.SkipDir
+			}
+
+			ok, err := hasIterables(path)
+			if ok {
+				add(path)
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dirs, nil
+}
+
+func skipDir(path, name string) bool {
+	if ignoredDir(name) {
+		return true
+	}
+
+	_, err := os.Stat(filepath.Join(path, "go.mod"))
+	return err == nil
+}
+
+// ignoredDir reports whether the directory with the given name is ignored
+// when walking directory trees, as the go tool does.
+func ignoredDir(name string) bool {
+	return name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// hasIterables reports whether the package in dir has a config file,
+// annotated types or files generated from annotations.
+func hasIterables(dir string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(dir, DefaultConfigFile)); err == nil {
+		return true, nil
+	}
+
+	// packages failing to be parsed are returned so their errors are
+	// reported along with the results of the other packages
+	_, annotations, err := findAnnotations(dir)
+	if err != nil || len(annotations) > 0 {
+		return true, nil
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".go" {
+			continue
+		}
+
+		_, cmd, err := readHeader(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return false, err
+		}
+
+		if cmd == annotationCommand {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	. "gopkg.in/check.v1"
+)
+
+type PackagesSuite struct {
+	dir string
+}
+
+var _ = Suite(&PackagesSuite{})
+
+func (s *PackagesSuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+
+	s.write(c, "ann/ann.go", "package ann\n\n//itergen:ops filter\ntype Order struct{}\n")
+	s.write(c, "cfg/cfg.go", "package cfg\n")
+	s.write(c, "cfg/itergen.json", `{"iterables": [{"type": "int", "filter": true}]}`)
+	s.write(c, "bad/bad.go", "package bad\n\n//itergen:ops chan,some\ntype Order struct{}\n")
+	s.write(c, "none/none.go", "package none\n")
+	s.write(c, "testdata/ann.go", "package ann\n\n//itergen:ops filter\ntype Order struct{}\n")
+	s.write(c, "mod/go.mod", "module mod\n")
+	s.write(c, "mod/ann.go", "package mod\n\n//itergen:ops filter\ntype Order struct{}\n")
+}
+
+func (s *PackagesSuite) write(c *C, file, code string) {
+	file = filepath.Join(s.dir, file)
+	c.Assert(os.MkdirAll(filepath.Dir(file), 0755), IsNil)
+	c.Assert(ioutil.WriteFile(file, []byte(code), 0644), IsNil)
+}
+
+func (s *PackagesSuite) path(dir string) string {
+	return filepath.Join(s.dir, dir)
+}
+
+func (s *PackagesSuite) TestMatchPackages(c *C) {
+	dirs, err := matchPackages([]string{s.dir + "/...", s.path("none"), s.path("ann")})
+	c.Assert(err, IsNil)
+	sort.Strings(dirs)
+	c.Assert(dirs, DeepEquals, []string{s.path("ann"), s.path("bad"), s.path("cfg"), s.path("none")})
+
+	_, err = matchPackages([]string{s.path("nope")})
+	c.Assert(err, NotNil)
+
+	_, err = matchPackages([]string{s.path("cfg/cfg.go")})
+	c.Assert(err, ErrorMatches, ".*cfg.go is not a package directory")
+}
+
+func (s *PackagesSuite) TestGeneratePackages(c *C) {
+	results, err := CheckPackages([]string{s.dir + "/..."})
+	c.Assert(err, IsNil)
+	c.Assert(results, HasLen, 3)
+	for _, r := range results {
+		c.Assert(r.Err, NotNil)
+	}
+
+	results, err = GeneratePackages([]string{s.dir + "/..."}, false)
+	c.Assert(err, IsNil)
+
+	errs := make(map[string]string)
+	files := make(map[string][]string)
+	for _, r := range results {
+		files[r.Dir] = r.Files
+		if r.Err != nil {
+			errs[r.Dir] = r.Err.Error()
+		}
+	}
+
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[s.path("bad")], Matches, `.*bad.go:3: //itergen:ops chan,some: --some is not supported by chan types, remove it or use a slice type`)
+	c.Assert(files[s.path("ann")], DeepEquals, []string{s.path("ann/order_iter.go")})
+	c.Assert(files[s.path("cfg")], DeepEquals, []string{s.path("cfg/int_iter.go")})
+
+	results, err = CheckPackages([]string{s.path("ann"), s.path("cfg")})
+	c.Assert(err, IsNil)
+	c.Assert(results, HasLen, 2)
+	for _, r := range results {
+		c.Assert(r.Err, IsNil)
+	}
+
+	// packages with files generated from annotations that no longer exist
+	// are matched to remove them
+	s.write(c, "ann/ann.go", "package ann\n\ntype Order struct{}\n")
+	results, err = GeneratePackages([]string{s.dir + "/..."}, false)
+	c.Assert(err, IsNil)
+	c.Assert(results, HasLen, 3)
+	_, err = os.Stat(s.path("ann/order_iter.go"))
+	c.Assert(os.IsNotExist(err), Equals, true)
+}
+
+func (s *PackagesSuite) TestGeneratePackagesIgnoresGOPACKAGE(c *C) {
+	env := os.Getenv("GOPACKAGE")
+	defer os.Setenv("GOPACKAGE", env)
+	c.Assert(os.Setenv("GOPACKAGE", "mw"), IsNil)
+
+	results, err := GeneratePackages([]string{s.path("cfg"), s.path("ann")}, false)
+	c.Assert(err, IsNil)
+	c.Assert(results, HasLen, 2)
+	for _, r := range results {
+		c.Assert(r.Err, IsNil)
+	}
+
+	code := mustRead(c, s.path("cfg/int_iter.go"))
+	c.Assert(string(code), Matches, "(?s).*\npackage cfg\n.*")
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+)
+
+// Plan describes the code that would be generated for an iterable.
+type Plan struct {
+	// File is the file the code would be written to.
+	File string `json:"file"`
+	// Types are the names of the generated types.
+	Types []string `json:"types"`
+	// Functions and Methods are the signatures of the generated functions
+	// and methods.
+	Functions []string `json:"functions"`
+	Methods   []string `json:"methods"`
+}
+
+// Plan returns the plan of the code that Generate would write, without
+// writing anything.
+func (g *Generator) Plan() (*Plan, error) {
+	code, file, err := g.GenerateSource()
+	if err != nil {
+		return nil, err
+	}
+
+	return planSource(file, code)
+}
+
+// PlanConfig returns the plans of all the iterables in the given config file.
+func PlanConfig(file string) ([]*Plan, error) {
+	config, err := LoadConfig(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		plans       []*Plan
+		errs        []string
+		gens, names = configGenerators(file, config, false, false)
+	)
+
+	shareLoader(gens)
+
+	for i, g := range gens {
+		plan, err := g.Plan()
+		if err != nil {
+			errs = append(errs, batchProblems(names[i], err)...)
+			continue
+		}
+		plans = append(plans, plan)
+	}
+
+	if len(errs) > 0 {
+		return nil, validationError(errs)
+	}
+
+	return plans, nil
+}
+
+func planSource(file string, code []byte) (*Plan, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, code, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{File: file, Types: []string{}, Functions: []string{}, Methods: []string{}}
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range decl.Specs {
+				plan.Types = append(plan.Types, spec.(*ast.TypeSpec).Name.Name)
+			}
+		case *ast.FuncDecl:
+			signature, err := funcSignature(fset, decl)
+			if err != nil {
+				return nil, err
+			}
+
+			if decl.Recv != nil {
+				plan.Methods = append(plan.Methods, signature)
+			} else {
+				plan.Functions = append(plan.Functions, signature)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// funcSignature returns the declaration of the given function without its
+// body.
+func funcSignature(fset *token.FileSet, decl *ast.FuncDecl) (string, error) {
+	signature := *decl
+	signature.Doc = nil
+	signature.Body = nil
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, &signature); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type PlanSuite struct{}
+
+var _ = Suite(&PlanSuite{})
+
+func (s *PlanSuite) TestPlan(c *C) {
+	g := New(Options{Type: "float64", Package: "foo", Map: []string{"int"}, Filter: true})
+	plan, err := g.Plan()
+	c.Assert(err, IsNil)
+	c.Assert(plan, DeepEquals, &Plan{
+		File:      "float64_iter.go",
+		Types:     []string{"Float64Iter", "Float64IterMapResult"},
+		Functions: []string{"func NewFloat64Iter(items ...float64) Float64Iter"},
+		Methods: []string{
+			"func (i Float64Iter) Map(fn func(int, float64) interface{}) Float64IterMapResult",
+			"func (r Float64IterMapResult) Iter() (Float64Iter, error)",
+			"func (r Float64IterMapResult) ToInt() ([]int, error)",
+			"func (i Float64Iter) Filter(fn func(float64) bool) Float64Iter",
+		},
+	})
+
+	g = New(Options{Type: "chan int", Package: "foo", Some: true})
+	_, err = g.Plan()
+	c.Assert(err, ErrorMatches, "--some is not supported by chan types, remove it or use a slice type")
+}
+
+func (s *PlanSuite) TestPlanConfig(c *C) {
+	dir := c.MkDir()
+	file := filepath.Join(dir, DefaultConfigFile)
+	c.Assert(ioutil.WriteFile(file, []byte(`{
+		"package": "foo",
+		"iterables": [
+			{"type": "float64", "filter": true},
+			{"type": "chan string", "output": "names.go"}
+		]
+	}`), 0644), IsNil)
+
+	plans, err := PlanConfig(file)
+	c.Assert(err, IsNil)
+	c.Assert(plans, HasLen, 2)
+	c.Assert(plans[0].File, Equals, filepath.Join(dir, "float64_iter.go"))
+	c.Assert(plans[1].File, Equals, filepath.Join(dir, "names.go"))
+	c.Assert(plans[1].Types, DeepEquals, []string{"StringChanIter"})
+
+	files, err := ioutil.ReadDir(dir)
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 1)
+}
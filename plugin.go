@@ -0,0 +1,423 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// frontMatterDelim starts and ends the front matter of the templates that
+// define operations.
+const frontMatterDelim = "---"
+
+// Plugin is an operation defined by a template in a templates directory. The
+// template starts with a front matter describing the operation:
+//
+//	---
+//	name: partition
+//	description: split the elements in the ones a function returns true for and the rest
+//	slice: true
+//	chan: false
+//	params: key
+//	imports: sort, strings
+//	---
+//
+// name is the name of the option enabling the operation, the name of the file
+// by default. slice and chan report whether the operation can be generated
+// for slice and channel iterables, only slices by default. params are the
+// names of the types the operation needs, given with --name-param, and
+// imports are the packages used by the template, which must refer to them
+// with the pkg function.
+//
+// The rest of the file is the template of the code, used for both slice and
+// channel iterables, which receives the type of the iterable with the types of
+// the parameters in Params by name.
+type Plugin struct {
+	Operation
+	// File is the file the operation is defined in.
+	File string
+}
+
+// pluginData is the data the template of a plugin is executed with.
+type pluginData struct {
+	TypeDef
+	Params map[string]TypeDef
+}
+
+// pluginOp is an operation that is not built in enabled for an iterable.
+type pluginOp struct {
+	op     *Operation
+	params map[string]TypeDef
+}
+
+var pluginNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// LoadPlugins returns the operations defined by the templates with front
+// matter in dir, sorted by name.
+func LoadPlugins(dir string) ([]Plugin, error) {
+	plugins, err := loadPlugins(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Plugin
+	for _, name := range pluginNames(plugins) {
+		result = append(result, *plugins[name])
+	}
+
+	return result, nil
+}
+
+func loadPlugins(dir string) (map[string]*Plugin, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("can not read the templates directory: %s", err)
+	}
+
+	plugins := make(map[string]*Plugin)
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".tgo" {
+			continue
+		}
+
+		file := filepath.Join(dir, f.Name())
+		text, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.HasPrefix(text, []byte(frontMatterDelim+"\n")) {
+			continue
+		}
+
+		p, err := parsePlugin(file, text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", file, err)
+		}
+
+		if prev, ok := plugins[p.Name]; ok {
+			return nil, fmt.Errorf("%s: operation %s is already defined in %s", file, p.Name, prev.File)
+		}
+		plugins[p.Name] = p
+	}
+
+	return plugins, nil
+}
+
+// parsePlugin parses the plugin defined by the given template text, read from
+// the given file.
+func parsePlugin(file string, text []byte) (*Plugin, error) {
+	p := &Plugin{
+		Operation: Operation{
+			Name:  strings.TrimSuffix(filepath.Base(file), ".tgo"),
+			Slice: true,
+		},
+		File: file,
+	}
+
+	r := bufio.NewReader(bytes.NewReader(text))
+	// the first line is the opening delimiter
+	if _, err := r.ReadString('\n'); err != nil {
+		return nil, err
+	}
+
+	line := 1
+	for {
+		l, err := r.ReadString('\n')
+		if err == io.EOF {
+			return nil, fmt.Errorf("front matter is not closed with %s", frontMatterDelim)
+		} else if err != nil {
+			return nil, err
+		}
+		line++
+
+		l = strings.TrimSpace(l)
+		if l == frontMatterDelim {
+			break
+		}
+
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+
+		if err := p.setField(l); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// the body keeps its line numbers in template errors
+	tpl, err := template.New(file).Funcs(funcs).Parse(strings.Repeat("\n", line) + string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	p.Template, p.ChanTemplate = string(body), string(body)
+	p.ChanImports = p.Imports
+	p.tpl, p.chanTpl = tpl, tpl
+	return p, nil
+}
+
+// setField sets the field of the front matter in the given "key: value" line.
+func (p *Plugin) setField(line string) error {
+	i := strings.Index(line, ":")
+	if i < 0 {
+		return fmt.Errorf("invalid front matter line %q, expecting key: value", line)
+	}
+
+	key, value := strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+	switch key {
+	case "name":
+		p.Name = value
+	case "description":
+		p.Description = value
+	case "slice", "chan":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s value %q, expecting true or false", key, value)
+		}
+
+		if key == "slice" {
+			p.Slice = b
+		} else {
+			p.Chan = b
+		}
+	case "params":
+		p.Params = splitList(value)
+	case "imports":
+		p.Imports = splitList(value)
+	default:
+		return fmt.Errorf("unknown front matter key %q, expecting one of name, description, slice, chan, params, imports", key)
+	}
+
+	return nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// validate checks that the operation can be given as an option along with
+// the registered ones.
+func (o *Operation) validate() error {
+	if !pluginNameRegexp.MatchString(o.Name) {
+		return fmt.Errorf("invalid operation name %q, it must be lowercase words separated by dashes", o.Name)
+	}
+
+	if reservedOptions()[o.Name] {
+		return fmt.Errorf("operation %s clashes with the option --%s", o.Name, o.Name)
+	}
+
+	if !o.Slice && !o.Chan {
+		return fmt.Errorf("operation %s supports neither slice nor chan types", o.Name)
+	}
+
+	seen := make(map[string]bool)
+	for _, param := range o.Params {
+		if !pluginNameRegexp.MatchString(param) {
+			return fmt.Errorf("invalid parameter name %q, it must be lowercase words separated by dashes", param)
+		}
+
+		if seen[param] {
+			return fmt.Errorf("parameter %s is declared more than once", param)
+		}
+		seen[param] = true
+	}
+
+	return nil
+}
+
+// reservedOptions returns the names of the options that can not be used by
+// new operations, which are the ones of the generator, the command and the
+// registered operations.
+func reservedOptions() map[string]bool {
+	reserved := map[string]bool{"type": true, "single-file": true, "config": true, "help": true}
+	t := reflect.TypeOf(Generator{})
+	for i := 0; i < t.NumField(); i++ {
+		if long := t.Field(i).Tag.Get("long"); long != "" {
+			reserved[long] = true
+		}
+	}
+
+	for _, op := range operations {
+		reserved[op.Name] = true
+	}
+
+	return reserved
+}
+
+func pluginNames(plugins map[string]*Plugin) []string {
+	var names []string
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadTemplates loads the templates and the plugins in the Templates
+// directory and prepares the enabled operations that are not built in,
+// returning the problems found. Types must have been parsed before.
+func (g *Generator) loadTemplates() []string {
+	g.templates, g.pluginOps = nil, nil
+	if g.Templates == "" {
+		return g.preparePlugins(nil)
+	}
+
+	templates, err := loadTemplateDir(g.Templates)
+	if err != nil {
+		return []string{fmt.Sprintf("--templates %q: %s", g.Templates, err)}
+	}
+
+	plugins, err := loadPlugins(g.Templates)
+	if err != nil {
+		return []string{fmt.Sprintf("--templates %q: %s", g.Templates, err)}
+	}
+
+	g.templates = templates
+	return g.preparePlugins(plugins)
+}
+
+// preparePlugins parses the parameters of the enabled plugins, which must be
+// in the given ones or registered, returning the problems found.
+func (g *Generator) preparePlugins(plugins map[string]*Plugin) []string {
+	var problems []string
+	for _, name := range sortedPlugins(g.Plugins) {
+		var p *Operation
+		if plugin, ok := plugins[name]; ok {
+			p = &plugin.Operation
+		} else if op, ok := registeredOperation(name); ok {
+			p = op
+		} else if g.Templates == "" {
+			problems = append(problems, fmt.Sprintf("--%s is not a registered operation, give the templates directory defining it with --templates", name))
+			continue
+		} else {
+			problems = append(problems, fmt.Sprintf("--%s is not defined in the templates directory %s", name, g.Templates))
+			continue
+		}
+
+		if g.Type.Type != "" && !p.supports(g.Type) {
+			if g.Type.IsChan {
+				problems = append(problems, fmt.Sprintf("--%s is not supported by chan types, remove it or use a slice type", name))
+			} else {
+				problems = append(problems, fmt.Sprintf("--%s is only supported by chan types, remove it or use a chan type", name))
+			}
+			continue
+		}
+
+		op := pluginOp{op: p, params: make(map[string]TypeDef)}
+		values := g.Plugins[name]
+		for _, param := range sortedParams(values) {
+			if !containsString(p.Params, param) {
+				problems = append(problems, fmt.Sprintf("--%s-%s is not a parameter of --%s, expecting one of %s", name, param, name, paramNames(p)))
+			}
+		}
+
+		for _, param := range p.Params {
+			raw, ok := values[param]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("--%s-%s is required by --%s", name, param, name))
+				continue
+			}
+
+			td, err := g.parseType(raw)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("--%s-%s %q: %s", name, param, raw, err))
+				continue
+			}
+			op.params[param] = td
+		}
+
+		g.pluginOps = append(g.pluginOps, op)
+	}
+
+	return problems
+}
+
+func paramNames(p *Operation) string {
+	if len(p.Params) == 0 {
+		return "none"
+	}
+	return strings.Join(p.Params, ", ")
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedPlugins(plugins map[string]map[string]string) []string {
+	var names []string
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedParams(params map[string]string) []string {
+	var names []string
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// pluginArgs returns the options of the enabled plugins in a command, with
+// the values that need it quoted with the given function.
+func (g *Generator) pluginArgs(quote func(string) string) []string {
+	var args []string
+	for _, name := range sortedPlugins(g.Plugins) {
+		args = append(args, "--"+name)
+		for _, param := range sortedParams(g.Plugins[name]) {
+			args = append(args, commandArg(name+"-"+param, g.Plugins[name][param], quote))
+		}
+	}
+	return args
+}
+
+// generatePlugins generates the code of the enabled operations that are not
+// built in.
+func (g *Generator) generatePlugins(w io.Writer) error {
+	for _, op := range g.pluginOps {
+		tpl, err := op.op.template(g.Type).Clone()
+		if err != nil {
+			return err
+		}
+
+		data := pluginData{TypeDef: g.Type, Params: op.params}
+		if err := tpl.Funcs(g.funcs()).Execute(w, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
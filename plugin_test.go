@@ -0,0 +1,197 @@
+package generator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type PluginSuite struct {
+	dir string
+}
+
+var _ = Suite(&PluginSuite{})
+
+const partitionPlugin = `---
+name: partition
+description: split the elements in two
+slice: true
+---
+
+func (i {{.Name}}Iter) Partition(fn func({{.Type}}) bool) ({{.Name}}Iter, {{.Name}}Iter) {
+  var in, out []{{.Type}}
+  for _, item := range i {
+    if fn(item) {
+      in = append(in, item)
+    } else {
+      out = append(out, item)
+    }
+  }
+  return in, out
+}
+`
+
+const groupByPlugin = `---
+# the name is taken from the file
+description: group the elements by a key
+chan: true
+params: key
+imports: sort
+---
+
+func (i {{.Name}}Iter) GroupBy(fn func({{.Type}}) {{.Params.key.Type}}) map[{{.Params.key.Type}}][]{{.Type}} {
+  result := make(map[{{.Params.key.Type}}][]{{.Type}})
+  for _, item := range i {
+    result[fn(item)] = append(result[fn(item)], item)
+  }
+  {{pkg "sort"}}.Ints(nil)
+  return result
+}
+`
+
+func (s *PluginSuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+	s.write(c, "partition.tgo", partitionPlugin)
+	s.write(c, "group-by.tgo", groupByPlugin)
+	s.write(c, "type.tgo", typeText)
+}
+
+func (s *PluginSuite) write(c *C, file, content string) {
+	c.Assert(ioutil.WriteFile(filepath.Join(s.dir, file), []byte(content), 0644), IsNil)
+}
+
+func (s *PluginSuite) TestLoadPlugins(c *C) {
+	plugins, err := LoadPlugins(s.dir)
+	c.Assert(err, IsNil)
+	c.Assert(plugins, HasLen, 2)
+
+	for i, p := range plugins {
+		c.Assert(p.Template, Matches, "(?s)\nfunc \\(i \\{\\{.Name\\}\\}Iter\\) .*")
+		c.Assert(p.ChanTemplate, Equals, p.Template)
+		plugins[i].Template, plugins[i].ChanTemplate = "", ""
+		plugins[i].tpl, plugins[i].chanTpl = nil, nil
+	}
+
+	c.Assert(plugins, DeepEquals, []Plugin{
+		{
+			Operation: Operation{
+				Name:        "group-by",
+				Description: "group the elements by a key",
+				Slice:       true,
+				Chan:        true,
+				Params:      []string{"key"},
+				Imports:     []string{"sort"},
+				ChanImports: []string{"sort"},
+			},
+			File: filepath.Join(s.dir, "group-by.tgo"),
+		},
+		{
+			Operation: Operation{Name: "partition", Description: "split the elements in two", Slice: true},
+			File:      filepath.Join(s.dir, "partition.tgo"),
+		},
+	})
+}
+
+func (s *PluginSuite) TestLoadPluginsErrors(c *C) {
+	cases := []struct {
+		content string
+		err     string
+	}{
+		{"---\nname: partition\n", ".*bad.tgo: front matter is not closed with ---"},
+		{"---\nname partition\n---\n", `.*bad.tgo: line 2: invalid front matter line "name partition", expecting key: value`},
+		{"---\nflag: x\n---\n", `.*bad.tgo: line 2: unknown front matter key "flag", expecting one of .*`},
+		{"---\nchan: maybe\n---\n", `.*bad.tgo: line 2: invalid chan value "maybe", expecting true or false`},
+		{"---\nname: Window\n---\n", `.*bad.tgo: invalid operation name "Window", it must be lowercase words separated by dashes`},
+		{"---\nname: filter\n---\n", `.*bad.tgo: operation filter clashes with the option --filter`},
+		{"---\nname: dir\n---\n", `.*bad.tgo: operation dir clashes with the option --dir`},
+		{"---\nslice: false\n---\n", `.*bad.tgo: operation bad supports neither slice nor chan types`},
+		{"---\nparams: key, key\n---\n", `.*bad.tgo: parameter key is declared more than once`},
+		{"---\n---\n{{.Name", `.*bad.tgo: template: .*bad.tgo:3: unclosed action`},
+		{"---\nname: partition\n---\n", `.*partition.tgo: operation partition is already defined in .*bad.tgo`},
+	}
+
+	for _, tt := range cases {
+		s.write(c, "bad.tgo", tt.content)
+		_, err := LoadPlugins(s.dir)
+		c.Assert(err, ErrorMatches, tt.err, Commentf("front matter %q", tt.content))
+	}
+}
+
+func (s *PluginSuite) TestGeneratePlugins(c *C) {
+	g := New(Options{
+		Type:      "float64",
+		Package:   "foo",
+		Filter:    true,
+		Templates: s.dir,
+		Plugins: map[string]map[string]string{
+			"partition": nil,
+			"group-by":  {"key": "time:time.Duration"},
+		},
+	})
+
+	code, _, err := g.GenerateSource()
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Matches, `(?s).*// go-itergen --type=float64 --pkg=foo --filter --templates=\S+ --group-by --group-by-key=time:time.Duration --partition\n.*`)
+	c.Assert(string(code), Matches, `(?s).*import \(\n\t"sort"\n\t"time"\n\).*`)
+	c.Assert(string(code), Matches, `(?s).*func \(i Float64Iter\) Filter.*func \(i Float64Iter\) GroupBy\(fn func\(float64\) time.Duration\) map\[time.Duration\]\[\]float64 \{.*func \(i Float64Iter\) Partition.*`)
+}
+
+func (s *PluginSuite) TestGeneratePluginsValidation(c *C) {
+	g := New(Options{
+		Type:    "chan float64",
+		Package: "foo",
+		Plugins: map[string]map[string]string{"partition": nil},
+	})
+	_, _, err := g.GenerateSource()
+	c.Assert(err, ErrorMatches, "--partition is not a registered operation, give the templates directory defining it with --templates")
+
+	g = New(Options{
+		Type:      "chan float64",
+		Package:   "foo",
+		Templates: s.dir,
+		Plugins: map[string]map[string]string{
+			"partition": nil,
+			"window":    nil,
+			"group-by":  {"size": "int"},
+		},
+	})
+	_, _, err = g.GenerateSource()
+	c.Assert(err, DeepEquals, &ValidationError{[]string{
+		"--group-by-size is not a parameter of --group-by, expecting one of key",
+		"--group-by-key is required by --group-by",
+		"--partition is not supported by chan types, remove it or use a slice type",
+		"--window is not defined in the templates directory " + s.dir,
+	}})
+
+	g = New(Options{
+		Type:      "float64",
+		Package:   "foo",
+		Templates: s.dir,
+		Plugins:   map[string]map[string]string{"group-by": {"key": "map[string"}},
+	})
+	_, _, err = g.GenerateSource()
+	c.Assert(err, ErrorMatches, `--group-by-key "map\[string": invalid type given: .*`)
+}
+
+func (s *PluginSuite) TestGenerateConfigPlugins(c *C) {
+	config := `{
+		"package": "foo",
+		"templates": "templates",
+		"iterables": [{"type": "float64", "plugins": {"partition": {}}}]
+	}`
+
+	dir := c.MkDir()
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, DefaultConfigFile), []byte(config), 0644), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "foo.go"), []byte("package foo\n"), 0644), IsNil)
+
+	tpls := filepath.Join(dir, "templates")
+	_, err := DumpTemplates(tpls, false)
+	c.Assert(err, IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(tpls, "partition.tgo"), []byte(partitionPlugin), 0644), IsNil)
+
+	c.Assert(GenerateConfig(filepath.Join(dir, DefaultConfigFile), false), IsNil)
+	code, err := ioutil.ReadFile(filepath.Join(dir, "float64_iter.go"))
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Matches, `(?s).*func \(i Float64Iter\) Partition.*`)
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// allPreset is the preset enabling all the operations enabled with a boolean
+// option.
+const allPreset = "all"
+
+// presets are the operations enabled by each preset besides all, which must
+// be enabled with a boolean option. Operations not supported by the kind of
+// the iterable are skipped.
+var presets = map[string][]string{
+	"functional": {"filter", "all", "some", "find", "foreach"},
+	"pipeline":   {"filter", "foreach", "concat", "array"},
+}
+
+func presetNames() string {
+	names := []string{allPreset}
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// presetOps returns the operations enabled by the preset with the given name
+// and whether it exists.
+func presetOps(name string) ([]string, bool) {
+	if name != allPreset {
+		ops, ok := presets[name]
+		return ops, ok
+	}
+
+	var ops []string
+	for _, op := range operations {
+		if op.flag != nil {
+			ops = append(ops, op.Name)
+		}
+	}
+	return ops, true
+}
+
+// applyPreset enables the operations of the preset and, with AllOps, all the
+// operations supported by the iterable. Types must have been parsed before.
+func (g *Generator) applyPreset() error {
+	var names []string
+	if g.Preset != "" {
+		ops, ok := presetOps(g.Preset)
+		if !ok {
+			return fmt.Errorf("invalid preset given: %s, expecting one of %s", g.Preset, presetNames())
+		}
+		names = append(names, ops...)
+	}
+
+	if g.AllOps {
+		ops, _ := presetOps(allPreset)
+		names = append(names, ops...)
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	// the header must show the preset, not the operations it enables
+	if g.origin == "" {
+		g.origin = g.command()
+	}
+
+	for _, op := range operations {
+		for _, name := range names {
+			if op.Name == name && op.flag != nil && op.supports(g.Type) {
+				*op.flag(g) = true
+			}
+		}
+	}
+
+	return nil
+}
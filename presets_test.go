@@ -0,0 +1,71 @@
+package generator
+
+import . "gopkg.in/check.v1"
+
+type PresetsSuite struct{}
+
+var _ = Suite(&PresetsSuite{})
+
+func (s *PresetsSuite) TestApplyPreset(c *C) {
+	tcs := []struct {
+		opts     Options
+		expected Options
+	}{
+		{
+			Options{Type: "int", Preset: "all"},
+			Options{Type: "int", Preset: "all", Filter: true, All: true, Some: true, ForEach: true, Concat: true, Find: true, Reverse: true, Splice: true},
+		},
+		{
+			Options{Type: "chan int", AllOps: true},
+			Options{Type: "chan int", AllOps: true, Filter: true, ForEach: true, Concat: true, Array: true},
+		},
+		{
+			Options{Type: "chan int", Preset: "functional", Reverse: true},
+			Options{Type: "chan int", Preset: "functional", Filter: true, ForEach: true, Reverse: true},
+		},
+		{
+			Options{Type: "int", Preset: "pipeline"},
+			Options{Type: "int", Preset: "pipeline", Filter: true, ForEach: true, Concat: true},
+		},
+		{
+			Options{Type: "int", Some: true},
+			Options{Type: "int", Some: true},
+		},
+	}
+
+	for _, tc := range tcs {
+		g := New(tc.opts)
+		c.Assert(g.parseTypes(), IsNil)
+		c.Assert(g.applyPreset(), IsNil)
+		c.Assert(g, DeepEquals, func() *Generator {
+			expected := New(tc.expected)
+			expected.Type = g.Type
+			expected.origin = g.origin
+			return expected
+		}())
+	}
+
+	g := New(Options{Type: "int", Preset: "everything"})
+	c.Assert(g.parseTypes(), IsNil)
+	c.Assert(g.applyPreset(), ErrorMatches, "invalid preset given: everything, expecting one of all, functional, pipeline")
+}
+
+func (s *PresetsSuite) TestPresetHeader(c *C) {
+	g := New(Options{Type: "chan int", Package: "foo", Preset: "pipeline"})
+	code, _, err := g.GenerateSource()
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Matches, `(?s).*// go-itergen --type='chan int' --pkg=foo --preset=pipeline\n.*func \(i IntChanIter\) Array.*`)
+
+	// explicitly given operations are still validated
+	g = New(Options{Type: "chan int", Package: "foo", Preset: "pipeline", Some: true})
+	_, _, err = g.GenerateSource()
+	c.Assert(err, ErrorMatches, "--some is not supported by chan types, remove it or use a slice type")
+}
+
+func (s *PresetsSuite) TestPresetsEnableFlagOperations(c *C) {
+	for name, ops := range presets {
+		for _, op := range ops {
+			c.Assert(isFlagOperation(op), Equals, true, Commentf("operation %s of preset %s", op, name))
+		}
+	}
+}
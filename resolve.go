@@ -0,0 +1,428 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// resolver resolves the types referenced by type specs, loading the packages
+// they come from and the package in the output directory.
+type resolver struct {
+	dir      string
+	fset     *token.FileSet
+	loader   *loader
+	importer types.ImporterFrom
+	pkgs     map[string]*types.Package
+	errs     map[string]error
+
+	// local are the names of the types declared in the package in dir and
+	// imports the packages imported by its files, by name.
+	local   map[string]bool
+	imports map[string]string
+	// pkgName and files are the name and the parsed files of the package
+	// in dir, if any.
+	pkgName string
+	files   []*ast.File
+}
+
+// newResolver returns a resolver of the package in the given absolute
+// directory, which loads packages with the given loader.
+func newResolver(dir string, l *loader) (*resolver, error) {
+	r := &resolver{
+		dir:      dir,
+		fset:     l.fset,
+		loader:   l,
+		importer: l.importer(dir),
+		pkgs:     make(map[string]*types.Package),
+		errs:     make(map[string]error),
+		local:    make(map[string]bool),
+		imports:  make(map[string]string),
+	}
+
+	return r, r.parseDir()
+}
+
+// parseDir collects the declared types and the imports of the package in the
+// resolver directory, which may not exist yet.
+func (r *resolver) parseDir() error {
+	if _, err := os.Stat(r.dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	pkg, err := build.ImportDir(r.dir, 0)
+	if err != nil {
+		if _, ok := err.(*build.NoGoError); ok {
+			return nil
+		}
+		return err
+	}
+
+	r.pkgName = pkg.Name
+	for _, name := range pkg.GoFiles {
+		f, err := parser.ParseFile(r.fset, filepath.Join(r.dir, name), nil, 0)
+		if err != nil {
+			return err
+		}
+		r.files = append(r.files, f)
+
+		for _, imp := range f.Imports {
+			path, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				return err
+			}
+
+			name := filepath.Base(path)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			r.imports[name] = path
+		}
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				r.local[spec.(*ast.TypeSpec).Name.Name] = true
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *resolver) load(path string) (*types.Package, error) {
+	if pkg, ok := r.pkgs[path]; ok {
+		return pkg, nil
+	}
+
+	if err, ok := r.errs[path]; ok {
+		return nil, err
+	}
+
+	pkg, err := r.importer.ImportFrom(path, r.dir, 0)
+	if err != nil {
+		err = fmt.Errorf("package %q could not be loaded: %s", path, err)
+		r.errs[path] = err
+		return nil, err
+	}
+
+	r.pkgs[path] = pkg
+	return pkg, nil
+}
+
+// resolve checks that all the types referenced by the given type exist.
+// Packages referenced without an import are looked up in the imports of the
+// package being generated and then in the standard library, and added to the
+// type imports if found.
+func (r *resolver) resolve(t *TypeDef) error {
+	expr, err := parser.ParseExpr(t.Type)
+	if err != nil {
+		return err
+	}
+
+	var refs []ast.Expr
+	collectTypeRefs(expr, &refs)
+
+	for _, ref := range refs {
+		switch ref := ref.(type) {
+		case *ast.Ident:
+			if err := r.resolveLocal(ref.Name); err != nil {
+				return err
+			}
+		case *ast.SelectorExpr:
+			if err := r.resolveQualified(t, ref.X.(*ast.Ident).Name, ref.Sel.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *resolver) resolveLocal(name string) error {
+	if _, ok := types.Universe.Lookup(name).(*types.TypeName); ok {
+		return nil
+	}
+
+	if !r.local[name] {
+		return fmt.Errorf("type %s does not exist in the package in %s", name, r.dir)
+	}
+
+	return nil
+}
+
+func (r *resolver) resolveQualified(t *TypeDef, qualifier, name string) error {
+	imp, ok := r.findImport(t, qualifier)
+	if !ok {
+		inferred, err := r.inferImport(qualifier)
+		given, hasGiven := singleUnaliasedImport(typeImports(*t))
+		switch {
+		case err == nil:
+			imp = inferred
+			t.Imports = append(t.Imports, imp)
+			if t.Package == "" {
+				t.Package = imp.Path
+			}
+		case hasGiven:
+			// the import path was given, just not the alias it needs
+			imp = given
+		default:
+			return err
+		}
+	}
+
+	pkg, err := r.load(imp.Path)
+	if err != nil {
+		return err
+	}
+
+	if imp.Alias == "" && pkg.Name() != qualifier {
+		return fmt.Errorf(
+			"package %q is named %s, not %s, import it as %q",
+			imp.Path, pkg.Name(), qualifier, imp.Path+" as "+qualifier,
+		)
+	}
+
+	// the generated code imports the package with the name it is referred
+	// to by when it does not match its path, e.g. github.com/foo/go-bar
+	if imp.Alias == "" && imp.name() != qualifier {
+		setImportAlias(t, imp.Path, qualifier)
+	}
+
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		return fmt.Errorf("type %s.%s does not exist in package %q", qualifier, name, imp.Path)
+	}
+
+	if _, ok := obj.(*types.TypeName); !ok {
+		return fmt.Errorf("%s.%s in package %q is not a type", qualifier, name, imp.Path)
+	}
+
+	if !obj.Exported() {
+		return fmt.Errorf("type %s.%s in package %q is not exported", qualifier, name, imp.Path)
+	}
+
+	return nil
+}
+
+// findImport returns the import of the type that is referred to with the
+// given qualifier, that is, the one with that alias or, when there is no
+// alias, whose path ends with it.
+func (r *resolver) findImport(t *TypeDef, qualifier string) (Import, bool) {
+	imports := typeImports(*t)
+	for _, imp := range imports {
+		if imp.Alias == qualifier {
+			return imp, true
+		}
+	}
+
+	for _, imp := range imports {
+		if imp.Alias == "" && imp.name() == qualifier {
+			return imp, true
+		}
+	}
+
+	// The only import of the type may be named differently than the last
+	// element of its path, e.g. github.com/foo/go-bar.
+	if imp, ok := singleUnaliasedImport(imports); ok {
+		if pkg, err := r.load(imp.Path); err == nil && pkg.Name() == qualifier {
+			return imp, true
+		}
+	}
+
+	return Import{}, false
+}
+
+// setImportAlias sets the alias of the import of the type with the given
+// path, adding it if the path is only the package of the type.
+func setImportAlias(t *TypeDef, path, alias string) {
+	for i, imp := range t.Imports {
+		if imp.Path == path && imp.Alias == "" {
+			t.Imports[i].Alias = alias
+			return
+		}
+	}
+
+	t.Imports = append(t.Imports, Import{Path: path, Alias: alias})
+}
+
+func singleUnaliasedImport(imports []Import) (Import, bool) {
+	var unaliased []Import
+	for _, imp := range imports {
+		if imp.Alias == "" {
+			unaliased = append(unaliased, imp)
+		}
+	}
+
+	if len(unaliased) != 1 {
+		return Import{}, false
+	}
+	return unaliased[0], true
+}
+
+// inferImport returns the import for a package referred to by the given
+// qualifier that has not been given an import path.
+func (r *resolver) inferImport(qualifier string) (Import, error) {
+	candidates := []string{qualifier}
+	if path, ok := r.imports[qualifier]; ok {
+		candidates = []string{path, qualifier}
+	}
+
+	for _, path := range candidates {
+		pkg, err := r.load(path)
+		if err != nil {
+			continue
+		}
+
+		if pkg.Name() == qualifier {
+			return Import{Path: path}, nil
+		}
+
+		if path != qualifier {
+			return Import{Path: path, Alias: qualifier}, nil
+		}
+	}
+
+	return Import{}, fmt.Errorf(
+		"cannot find package %s, give its import path with %q",
+		qualifier, "path/to/"+qualifier+":"+qualifier+".Type",
+	)
+}
+
+// collectTypeRefs collects all the references to named types in the given
+// type expression, which are either identifiers or qualified identifiers.
+func collectTypeRefs(expr ast.Expr, refs *[]ast.Expr) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		*refs = append(*refs, e)
+	case *ast.SelectorExpr:
+		if _, ok := e.X.(*ast.Ident); ok {
+			*refs = append(*refs, e)
+		}
+	case *ast.ParenExpr:
+		collectTypeRefs(e.X, refs)
+	case *ast.StarExpr:
+		collectTypeRefs(e.X, refs)
+	case *ast.Ellipsis:
+		collectTypeRefs(e.Elt, refs)
+	case *ast.ArrayType:
+		collectTypeRefs(e.Elt, refs)
+	case *ast.MapType:
+		collectTypeRefs(e.Key, refs)
+		collectTypeRefs(e.Value, refs)
+	case *ast.ChanType:
+		collectTypeRefs(e.Value, refs)
+	case *ast.IndexExpr:
+		collectTypeRefs(e.X, refs)
+		collectTypeRefs(e.Index, refs)
+	case *ast.IndexListExpr:
+		collectTypeRefs(e.X, refs)
+		for _, idx := range e.Indices {
+			collectTypeRefs(idx, refs)
+		}
+	case *ast.FuncType:
+		collectFieldRefs(e.Params, refs)
+		collectFieldRefs(e.Results, refs)
+	case *ast.StructType:
+		collectFieldRefs(e.Fields, refs)
+	case *ast.InterfaceType:
+		collectFieldRefs(e.Methods, refs)
+	}
+}
+
+func collectFieldRefs(fields *ast.FieldList, refs *[]ast.Expr) {
+	if fields == nil {
+		return
+	}
+
+	for _, f := range fields.List {
+		collectTypeRefs(f.Type, refs)
+	}
+}
+
+// resolveTypes checks that all the types to generate code for exist, using
+// dir as the directory of the package the code is generated in. The error
+// has a problem for every type that does not. Packages are loaded with the
+// loader of the generator, if any, or with a new one.
+func (g *Generator) resolveTypes(dir string) error {
+	l := g.loader
+	if l == nil {
+		l = newLoader()
+	}
+
+	r, err := l.resolver(dir)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	if err := r.resolve(&g.Type); err != nil {
+		problems = append(problems, fmt.Sprintf("-t %q: %s", g.RawType, err))
+	}
+
+	for i := range g.MapResults {
+		if err := r.resolve(&g.MapResults[i]); err != nil {
+			problems = append(problems, fmt.Sprintf("--map %q: %s", g.Map[i], err))
+		}
+	}
+
+	for i := range g.ReduceTypes {
+		if err := r.resolve(&g.ReduceTypes[i]); err != nil {
+			problems = append(problems, fmt.Sprintf("--reduce %q: %s", g.Reduce[i], err))
+		}
+	}
+
+	for _, op := range g.pluginOps {
+		for _, param := range op.op.Params {
+			td := op.params[param]
+			if err := r.resolve(&td); err != nil {
+				problems = append(problems, fmt.Sprintf("--%s-%s %q: %s", op.op.Name, param, g.Plugins[op.op.Name][param], err))
+			}
+			op.params[param] = td
+		}
+	}
+
+	if len(problems) > 0 {
+		return validationError(problems)
+	}
+
+	defs := []*TypeDef{&g.Type}
+	for i := range g.MapResults {
+		defs = append(defs, &g.MapResults[i])
+	}
+
+	for i := range g.ReduceTypes {
+		defs = append(defs, &g.ReduceTypes[i])
+	}
+
+	var params []TypeDef
+	for _, op := range g.pluginOps {
+		for _, param := range op.op.Params {
+			params = append(params, op.params[param])
+		}
+	}
+
+	for i := range params {
+		defs = append(defs, &params[i])
+	}
+
+	r.describe(defs)
+
+	for _, op := range g.pluginOps {
+		for _, param := range op.op.Params {
+			op.params[param], params = params[0], params[1:]
+		}
+	}
+
+	return nil
+}
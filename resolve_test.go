@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type ResolveSuite struct {
+	dir string
+}
+
+var _ = Suite(&ResolveSuite{})
+
+const resolvePkg = `package foo
+
+import tm "time"
+
+type Order struct {
+	Created tm.Time
+}
+`
+
+func (s *ResolveSuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+	file := filepath.Join(s.dir, "foo.go")
+	c.Assert(ioutil.WriteFile(file, []byte(resolvePkg), 0644), IsNil)
+}
+
+func (s *ResolveSuite) TestResolveTypes(c *C) {
+	tcs := []struct {
+		raw     string
+		imports []Import
+	}{
+		{"int", nil},
+		{"Order", nil},
+		{"map[string][]*Order", nil},
+		{"os:*os.File", []Import{{Path: "os"}}},
+		{"*os.File", []Import{{Path: "os"}}},
+		{"chan func(io.Reader) error", []Import{{Path: "io"}}},
+		{"tm.Duration", []Import{{Path: "time", Alias: "tm"}}},
+		{"strings as str:str.Builder", []Import{{Path: "strings", Alias: "str"}}},
+	}
+
+	for _, tc := range tcs {
+		g := &Generator{RawType: tc.raw}
+		c.Assert(g.parseTypes(), IsNil)
+		c.Assert(g.resolveTypes(s.dir), IsNil, Commentf(tc.raw))
+		c.Assert(g.Type.Imports, DeepEquals, tc.imports, Commentf(tc.raw))
+	}
+}
+
+func (s *ResolveSuite) TestResolveTypesErrors(c *C) {
+	tcs := []struct {
+		g   *Generator
+		err string
+	}{
+		{
+			&Generator{RawType: "os:os.Fiel"},
+			`-t "os:os.Fiel": type os.Fiel does not exist in package "os"`,
+		},
+		{
+			&Generator{RawType: "os.file"},
+			`-t "os.file": type os.file in package "os" is not exported`,
+		},
+		{
+			&Generator{RawType: "time.Now"},
+			`-t "time.Now": time.Now in package "time" is not a type`,
+		},
+		{
+			&Generator{RawType: "nope.Foo"},
+			`-t "nope.Foo": cannot find package nope, give its import path with "path/to/nope:nope.Type"`,
+		},
+		{
+			&Generator{RawType: "Order", Map: []string{"Missing"}},
+			`--map "Missing": type Missing does not exist in the package in .*`,
+		},
+		{
+			&Generator{RawType: "Order", Reduce: []string{"nope/nope:nope.Foo"}},
+			`(?s)--reduce "nope/nope:nope.Foo": package "nope/nope" could not be loaded: .*`,
+		},
+		{
+			&Generator{RawType: "io, os:os.File", Map: []string{"strings:str.Builder"}},
+			`--map "strings:str.Builder": package "strings" is named strings, not str, import it as "strings as str"`,
+		},
+	}
+
+	for _, tc := range tcs {
+		c.Assert(tc.g.parseTypes(), IsNil)
+		c.Assert(tc.g.resolveTypes(s.dir), ErrorMatches, tc.err)
+	}
+}
@@ -8,6 +8,6 @@ import (
 )
 
 func init() {
-	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\xc4mDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x07\x00	\x00all.tgoUT\x05\x00\x01!BX\\,\xc9\xbd\nB1\x0c\xc5\xf1\xbdOq\xdcZ\x90\xfb\x00\x82\x83\xa3\x8b\x93\xbbTI\xa4\xd0\x9bJl\x07	yw\xb9\x1f\xc39\xc3\xff\x17x\xc8\x0b\xb1\xc0l\xba\xe5\x99\xdc\xaf\x9d4\xe1Rkd\xc1\xa2\xd1l\xba\xff>\xe4\x9e\xf0l\xadn\x0f\x0b\x007\xc5\xe3\x88\xd2i\xc6\xe9\x0c\xcd\xf2&\x94\x95\x80\xc28\xb0\xc4\x05\xd3\x9e\x00\xa5>T\xc0\xb9~iM\x1e\xb6\xed\xd0uP\xf0\xf0\x0f\x00\x00\xff\xffPK\x07\x08\xa3\xf2L\x98v\x00\x00\x00\x97\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0e\x00	\x00chan_array.tgoUT\x05\x00\x01w@X\\L\x8fOK\xc40\x10\xc5\xcf3\x9fb\x8e	h\xc0\xab\xb8\x87EA\xbcx\x12<\x88\x87\xd0L\xb3\x05M\xca4i)!\xdf]\xd2\xaeK\xdfm\xfe\xf0{\xefa\x9fCGj\xa0R\xcc\xbb\xfd\xe5Z\x9f/6\xbc%\x16Mg\x11\xbb*\x17\x03Sw\xb1\x81\xa6$\xb9K\xa5j\xfa\xfa.\xc5|\xac#\xd7J\x05a\xb6B\n\x01\x84\xa7\xfc\x93\x8eW\x04X<5Mk\xe8\xcc\xa7\x1d\xd2\xab\xc4<\"hDX\xbc9;\xa7\x1e4\x82\x8f\xd4\x92(\xddx\xd0G\xa1\x99\x1eO$6x\xa6a[\xfe\xe3Od\xc7\x91\x83S\xfb|G\xb3F\x80\xdd\xca\xbc\xc4\xc0J#T\xd5\x0c\x1c\xf7,G\xf0V\xe6\xe9\xfeV\xa5\xd4\xeb+]\xb5\xf8-dC\x08\xa7,\x81v\x17\xac\xf8\x17\x00\x00\xff\xffPK\x07\x08\xf1\xda\xe8\x10\xcc\x00\x00\x00+\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00chan_concat.tgoUT\x05\x00\x01w@X\\l\x90OK\x031\x10\xc5\xcf\xc9\xa7x\xc7,\xd4\x80Wq\x0fRA\xbcx\x12<\x88\xc8\x90M\xb7\x8b6Y\xf2gK	\xf9\xee2\xd1\xb2B{	d\xf8\xcd{o\xde.;\x035\xa1\x14\xfdB\x07[\xebvO\xee9\xd9\xd0a\xeb\x9d\xa1\xa4(\x8c\x11Z\xeb+\xc4\xc5\x08E\x8a\x85\x02\x94\x14\xc2\xe7\x04\xa0\xc7\x81\xbe\xac2{r\x8c\xbf\x9ef[k'\x858\x8e\x00\x10O\xce\xe87\x9a\xd2S\xf0y\x96B0\x18\xd1\xe3\xfd\xe3B\xbcLU\x8aNJ\xb1\xf3\x01\x9f\x1b\x10\xeez\x04r\xa3E\xcbX\xfe\xad\xd3<[74\xdb\xb8\x01uR\xd4u\xd1\xac\x8b\xbf|iy\xf4\xc30\xa8[\xce6zp/jr\xd7za\xbaI-\xab\x0e\x93<nW\xdf\xdf`\xe1O\xe5\xe78\xeaG\xef\xacb\xdd\xaa\xcc_\x92\xb3Cw\xf6\xe6\x0e\x1ac\xbe}\xb4\xca\xe7\xc4\xa4\xe2s\x83M98\xf8\x9cd\x95?\x01\x00\x00\xff\xffPK\x07\x08\xff&\xd8\xaa\xf3\x00\x00\x00\xb0\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00chan_filter.tgoUT\x05\x00\x01w@X\\d\x8d1\x8a\xc30\x10Ek\xcd)~)\x15\xeb\x03,\xbb\xd5\xc2B\x9aT\xb9\x80bF\xb6\x88\xad	\x8ad\x08b\xee\x1e\x14\x07Rd`\x8a\x19\xde\x7f\x9fBM#lDk\xc3\xd1\xaf\xac\xfa7\xfbt(\x9c\x1d\xfe\xe3R8\xdb\x90\xd0!\xdb\xdap\xba_Y\xd5\xe1,\xb2\xb8\xcf\x08\x1a\xe15R\x0b\xbe\x7f\xb1\xfa\x0b\xdbq\xf6	\xef4\x91\x99dW:42&H\xc6\xd6\xe9\xec\xd3\xc4\x88\xcf\xa7\x89\x01!\xd9mG\x8c\xe9\xc2\x9f/l\xfdP\xdaw\\\xe4\xc6Vjqd\xd4vq\xe6Rs\xea\xed\xa4\xf4\x08\x00\x00\xff\xffPK\x07\x08\xe9,\xa9\xac\x9d\x00\x00\x00\xdc\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00chan_foreach.tgoUT\x05\x00\x01w@X\\t\xcb1\xca\x021\x10\xc5\xf1>\xa7xe\xc2.\xdf\x01>\xb0\x12\x05\x1b+/\x10\x96d7\x85/2\xc4\x80\x84\xb9\xbb,j\x10\xd4W\xfe\xe77&^9\xc1&\xb4\xf6w\xf4\xe7\xa0\xba]<\x0f%\x88\xc3>\xcb\xceO\x8b\x8d\xc4\xaalb\x19Ww\xba]\x82\xaash\x06\xcfU/ \x12K/s~<\xbd\xab\xd7b\x16T\xfco \x9es@\xfaB:\xa5\xe5\x88\xea~\x02\x0e\xc3\xc7M{Q\xeb\x8c\x1as\x0f\x00\x00\xff\xffPK\x07\x08q\x18<\xe4\x80\x00\x00\x00\xe6\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0c\x00	\x00chan_map.tgoUT\x05\x00\x01w@X\\|\x91\xcdn\xea0\x10\x85\xd7\x9e\xa7\x98\xcb\xca\x16\x81\x07\xb8\x85U\xd5E\x17\xb0\xa8\xd8Wn\x98\x80\x15\xb0\xa3\xc1IA\x91\xdf\xbdr,~\x92\x08\xbc\x02\xe5\xcc7\xf6\xf9\xc0_*\xc2\xb6\x9d\xaf\xf5\x91Bx\xdfk\xbb\xd2\xd5\x17\x9d\xea\x83\xc7\xc5,\xdfk\x8b\xc6z\xe2B\xe7\xd4\x06\x80\xa2\xb69J\xd3\x1f\xf9\xf4\xc4\nW\xba\x92\x85\xc5\x98\x90\xc6\xfa,f6\x97\x8aBP\x8f\x0c\xf5l]\x0b\xc2\xd5\x1e\xff/\xf1\xa8K\x92\xc3\xdd\n@\xec\\\xc2+l\x01\x07\xa7\xd1\x8cf{\x8e# D\xe1\x18\x9b\xc8bmw\x84&\xd2E\xc7_\xcc\xb0\xb0\xd2l\xcf\x196jD\xb9\x1e\xb3=O\xa7 D\x00!\xf2\x83;\x91t\xb5W \x82\x8c\xf7`\xf25[t\xb5\x87\x00\x107\x7f0\xf7\x9e\xb5q\xb7\xbf\xb8Dbv|\x9a\xaf\xe9WNrm\xad\xf3\x98;\xdb\x10\xfbgex\x87]\x01\xb7\x0e'\xeaZ>?\x99Q\x18=H\x85r$'K\xb0\xee\x1a\x8f\xdd\x8d\xfa\xbe+\xbbe\x88\xb9\x9fI\x94\x81\x8dQ\xe3\x9c\x1a7\x05~g\xe8\xca\xf8\xa1\x99\xcb;\xff\x0d\xff\xb92eD\\\xb1\x98\xbd\xea\xb0\x8b\xfd0\xe92\xfe\n\x0f.{\xd0N\xd8Pe\xd2G\xccc\xdb\xaf\xccf\xf1\xa1Q\xef_\x00\x00\x00\xff\xffPK\x07\x08\xcfF\xf1cI\x01\x00\x00$\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x14\x00	\x00chan_map_results.tgoUT\x05\x00\x01w@X\\|\x90\xcfN\xc30\x0c\xc6\xcf\xc9S\x98i\x87D\xda\xfa\x00\xb0\x9d\x10Gv@\xbb\xa3P\xbc\x0du\x8d'7-B\x91\xdf\x1d9\xad\x80N\x1a>%\xf6\xf7\xfd\xfc'\xe7e\x0c-\xc2\xfd\x16\xaa]hQ$g\x0e\xf1\x88\xb0\xe4\x92|\xc1\xae?\xa7N\xc4\x0e\x81\xe1\x89yr\x88<\x9eB\xdcS\xce\x93\x0f\xb6\x80\xcc\xc4]\xb5\xc3O\xb7\xa8C\x8c\x94\xa0\xa68 '\x98\xb9\x9e\xc3e\xc4B\"\xa8O!B\xce\xd5\xfe\xeb\x82\"\x0bo\xed\xa1\x8f58\xbe\xe1\xf1\xf0\xa7\xa9\xf3\xe0\xe6\x80\xd5\x08,\xa3x\xc8\x16\xa6\xa0>\xe9>mh\xf0\xca\xe1\x7f4\xc8<\xd7\x8c\x14k\xcd\x91@\xa7rJ4\xe6@\x0c\x83*\xc7KqI\x9a\x8f\x03\xbc\xae\x80\x1a-\x0c\x95\xfb\xe5?\xc0\x1d5\xa3\xc6h\x8b\xcd\xfa\xbf;\x16\xd9\x1bch\xf4U\xbe:\xfbf=\x87Z\xad\xc1U\xd4g\xea\xd0!\xb3\xbfQ\xa2>yk\xc4\xe9N\x8c\xa9\xe7\xa8\x87Y\x95\xcd\xad\xe4\x8c\xf1]\xc4\xda\xef\x00\x00\x00\xff\xffPK\x07\x08\xc7\xa7=\xe7\x06\x01\x00\x00\x16\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00chan_reduce.tgoUT\x05\x00\x01w@X\\t\x921n\xc30\x0cEw\x9d\x82C\x06	q{\x80\xa2\x99:u\xe9P\xf4\x02\x82L'Bm*\x90\xa5\"\x01\xc1\xbb\x17\xb2\x15;\x81[N\xf6\xe7\xe7\xe3\x07!\xe6\x1d\xd9\x01\xe1\xe5\x00\xcf\x1fv@\x11\xe6]\xba\x9eg\xe5\xebzF\x11\xc5\x1c-\x1d\x11vqR?\xb1\xcd\x0e\xe3(\xa2\xbaL\x0e\xb4\x87\x8a\x11y;YzO\x18\x0d\xcc.\xe6\x8a\xd5\x1dAqk\x97cDJP\xf7\x884`\x9d\x03\xe6\xba\xad\x01O-^\xc0S2\x8f\xaaO\xde\xf6\xabd\xc0\x9d,\xad\xff\xc0\nj\x85\x9cJ\xd2\xc1~\xa3~4\x99\xc5\x13q\xcc\xfdd\xab\xe4\xa5\xb3|\x1c\xc3\x1c\xd9\xdc\xa1o\xf5c#\xf8v\x8a\xb9\xe9u!\x82O8\x14\xf8|9\xff\x07\xe1V5\xc8\x01:\xd2e\xaa\xa9JS\xf8\xe6\xdf1\xdf^\xf6\xfbMW\xd4F*\xc7x}\xaa\xd0M\xd7\xf5aD\x1drZ7\x896\xea\xeeL)G*\x10U\x1e\x02R+\xa2\xd4o\x00\x00\x00\xff\xffPK\x07\x08\xa5\x04\xb4\x82\xfe\x00\x00\x007\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x00chan_type.tgoUT\x05\x00\x01w@X\\*\xa9,HU\xa8\xae\xd6\xf3K\xccM\xad\xadu\xceH\xcc\xf3,I-RH\xceH\xcc\x03	\x87T\x16\xa4\xd6\xd6rq\x01\x02\x00\x00\xff\xffPK\x07\x08\xb6k\x13\x0b+\x00\x00\x00'\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00concat.tgoUT\x05\x00\x01w@X\\\xe2J+\xcdKV\xd0\xc8T\xa8\xae\xd6\xf3K\xccM\xad\xad\xf5,I-\xd2Tp\xce\xcfKN,\xd1\xc84B\x97@\xe1*Ts)(\x14\xa5\x96\x94\x16\xe5)$\x16\x14\xa4\xe6\xa5hd\xea(d\x1a\xe9\xe9\xe9ir\xd5r\x01\x02\x00\x00\xff\xffPK\x07\x08m\x9dUrJ\x00\x00\x00]\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00filter.tgoUT\x05\x00\x01w@X\\T\xce\xc1\xca\xc20\x0c\x07\xf0{\x9f\xe2\x7fl\xe0c\x0f\xf0\xc1\xae\x82\x17O\xdeD\xa4j*\x85-+\xb1\x15\xa4\xf4\xdd\xa5\xdb\x14v\xc8!\xc9/\x7fb|\x96\x1bl@)\xdd\xc1\x8d\\\xeb>\xb1\x12vaH\xac\xd6\x0b\x1a\xb0\xa5t\xc7w\xe4Z	\xd7i\x1ah\xcbQ\x0c\xf0r\n\xe5g\x1e\x12N\xe7\x9f7\x80\x9f\x14\x97?\x84\xc4#\xfe{\xa8\x93\x07#\xcc7@\xf0\xf0b\xdb\x8e\xd6	\xbe)=\\\x8c,w\xbb\xf4K\x02\xcd\xa6\xc5\xb6RNYe\xfb\xcc\xca\xc9T\xf3	\x00\x00\xff\xffPK\x07\x08\xb0\xc9m;\x93\x00\x00\x00\xdd\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x08\x00	\x00find.tgoUT\x05\x00\x01w@X\\D\x8d1\n\x021\x10E\xfb\x9c\xe2\x95	\xc4\x05[\xc1V\xb0\xb1\xf2\x02\xab&2\xe0&2d\x05\x0d\xb9\xbbdY\xb4\x98\xe6\xbd\xf9\xff\x9b8\xa7+V\xa8u8\x8dSh\xedX\x82:\x0e\x92n6&\xba\xb6\xb5\x0e\xe7\xf73\xb4\xe6\xb8\xe4\xfcp\xfc\x89GRqT\x03\xafQ\xf9\x04\xcd\xfc\xa4\x81\x98\x15\xf1H	\x13\xbb=:\xa6{@\x96\x7f\x90HL\xb6;\xb7\x12\xd0PfMK\xc0#\x0b\xec=\xfdV\xd57<\x9b\xadi\xe6\x1b\x00\x00\xff\xffPK\x07\x08\xcf\xd0m\xe8\x86\x00\x00\x00\xbe\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00foreach.tgoUT\x05\x00\x01w@X\\,\xca1\n\x021\x10@\xd1~N\xf1\xcb\x04\x16\x0f X*\xd8Xy\x81\xb0L4\xc5\xce\xca\x10\x0b	sw\x89X\xbf'\xf5m+\xa91\xc6\xe1V6\x8d\xb8v\xf5\xcce\xf7sY\x9f\xa9\x1as\xa4f}\x99\xe7\xfeyiD\xce\x0c\x81\xba;\xb6\xd0\xban\x1cOx\xb1\x87\xd2~\x04\xd5\xd2\xdf\xb2@H\xc87\x00\x00\xff\xffPK\x07\x08\xc3>iGb\x00\x00\x00l\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00imports.tgoUT\x05\x00\x01w@X\\\xaa\xae\xceLS\xd0\xab\xad\xcd\xcc-\xc8/*Q\xd0\xe0\xaa\xae.J\xccKOUP)\xc8NW\xb0\xb2\x05\xc9)((UW\x83\xf8\xb5\xb5J\\\xd5\xd5\xa9y)\xb5\xb5\x9aP\x9a\x0b\x10\x00\x00\xff\xffPK\x07\x08\xa8\x9a\xf2\x07=\x00\x00\x00A\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x07\x00	\x00map.tgoUT\x05\x00\x01w@X\\|\x91\xc1j\x021\x10\x86\xefy\x8a\xbf\x9e\x12\x08>@\x8b\xc7\x1ez\xd0C\xf1&R\xc2v\xb6\x04u\x12\xc6\xacE\x96\xbc{\x89\xc6\xb5\xdbv{\xdb\xf0\xcf\x97\x7f\xe7\x8bJ\xe7H\xe8\xfb\xf9\xca\x1d(\xe7\x97D\xb2t\xf1\x95\x8e\xdd>a\xb3\xf5\x9cHZ\xd7P\x9f\x95j;n\xa0\xfdx\xda`\xe9\xa2n\x19%\xd5\x9e\x93-\xf9\xfa\x1c)g\x83o\xbc\x99j\xe9\x15pr\x02\xf9\xab\x14h\x83\x80-|\xa2\x03\x1e\x17\x10\xc7\x1f\x04\x7f\xa1pc\x16p1\x12\xbf\xeb\xeb\xd9\xa2e]\x19c\x14P\xee\x11J\x9dp%TV\xaat>\x8b\x0c\x7f\xb5\x0e\xc3'\x16 \x91 \xc7\xf9\x8a>\xf5\xacq\xcc!\xa1	|\"IS{\xa4\x80\xcdv\xd8}fn\xc2d\x020(Gm\xa0G\xb9\xbdV\x9b\xdf^\x86\xbb\xab\x957\x0b\x7fW\"U\x89oK\x10v%\xf1s}\x7f\x8c'<\x84]\x1d\x1at\xb0\xdf\xdb)\x0b\x97\xc9\xfc\xaf\xe7Q\xc1\x0f\xd3\xa3\xad*aliTY}\x05\x00\x00\xff\xffPK\x07\x08\xc3\xe3\xde	\x08\x01\x00\x00x\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00map_results.tgoUT\x05\x00\x01w@X\\t\x90\xb1j\xc30\x10\x86w=\xc5\xdf\x90A\x02\xe3\x07h\xc9\xd8\xa1C3\x94l\xa5\x14\x91\x9c\x8b\x89s2g9\xa5\x1c\xf7\xee\xc5\x96\x1b\xdc!\x8b@\xba\xef\xbb\xffN\xaa[\x8e\x17\xc2\xe3\x0e\xf5>^\xc8LU\"\x7f\x11\xb62?\xbe\xd10vy0s\xd7(x\x16Y\x0c\xb3CR]\x1c\xec@\"I\x86zO\xdf~s\x8c\xcc)\xe3\x98\xf8J\x92q3^2\xc9k\xecKK\xe4\x84\xf7\x0f\xd5\xfa\xf0\xd3\x93\xd9&8\xd7\x8c|\x84\x97;B\xc0*\xd1\x07\xf8\x95]\x95\xfc\x00u\xc04\xa8\x94\x8c\x15\xe2\x80&	>+\xb4\xd3beI\x99\x05\xa0m\xa6B:O\x95\xb6\xf67)<\xe1!\x9d\x17\x08\x10\xca\xa30\xb8\xed\xaa{_1\x93\xe5\\f\xd8!\xf6=\xf1\xc9\x97{\xf5? \xb8\x82/\xad\xff\x18n;g\xaa\xc4'3\xf7\x1b\x00\x00\xff\xffPK\x07\x08X\x97 |\xe1\x00\x00\x00\xa5\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00reduce.tgoUT\x05\x00\x01w@X\\T\x90Aj\x031\x0cE\xf7>\xc5_\xccb\x06L\x0fP\xc8\x01\xba\xe9\xa2\xf4\x02\xc6#\x17CF\x0d\x8a\\\x12\x84\xee^\x9cq\x99f\xfb\xf4\xbe\xbe-\xb3\x89\xd3Fx=\xe1\xe5=m\xe4n6\xe9\xfd\xb2\x93\xcf\xfb\x85\xdc\x83\x99$\xfe\"L\xf2\xa0\x1f\xb4\xb6Lru\x0f\xa5q\xc6\\1\xd6\xb8\xbf)\xc9\x82\xdd0\x1b+\xe7\xc2\xe8\xe6\x9c\x9b\x08\xb1bt\xb8G\xa4\x9ca6\x9a\"*\xaftCe]\x9ei\xd5\x9a\xce\x07\xfa7\x85\x05\xe0'	\x84\xae\xed\xac8\xfd\xd9\x01(\xdf\x82\xba\xde\"\xaa\xd2\xd6\xdf\xbe\xff\xa3>28\x12\x85\xe7n\xc4Ab\x0f-\x01\xf0\xd0%m\xc2c\x12\xfa1\x88W\xf7\xf0\x1b\x00\x00\xff\xffPK\x07\x08\xd7\xb8\xe1\x1d\xba\x00\x00\x00:\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00reverse.tgoUT\x05\x00\x01w@X\\T\xcc\xbd\xaa\x021\x14\x04\xe0\xfe<\xc5\x94	\xdc,\xd7\xd6%\xf66\x16b\xb7l\x11\xf4,$\xac1\x9cM\x16$\xe4\xdd\xc5\xbf\xc2r\x98\xf9\x86\xa6\x12\xcfP\x1e\xb5v\x07w\xe5\xd6\xf6\x99E\xe3\xc8+\xcb\xc2J\xff\x16\xa8\x04\xacN \xbc\x949c\x18k\xedN\xf7\xc4\xad\x110\xdd\x04\x01[\x8b\x99\xa3\xf2\xdalz\x04\xec,\xfe{\x04c^\x16_i\xe1R\xe2xQ\xef\xfc\x07?\x84Q\x13\xf0<\x12\xceE\xe2gJ\x8d\x1e\x01\x00\x00\xff\xffPK\x07\x08[\xe5\xd4a\x85\x00\x00\x00\xa6\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x08\x00	\x00some.tgoUT\x05\x00\x01w@X\\,\xc9=\n\x021\x10\xc5\xf1>\xa7xe\x02\xb2\x07\x10<\x80\x8d\x8d\xf6\x12eF\x02\x9b\x89\x8cI!\xc3\xdc}\xd9\x8f\xe2\xbd\xe2\xff\x0b<\xe4\x8dX`6\xddr%\xf7k'M\xb8\xb7J\x91\x05+G\xb3\xe9\xf1\xff\x92{\xc2\xab\xb5y\x7fX\x00\xb8)\x9e'\x94N\x15\xe7\x0b4\xcb\x87P6\x02\n\x83%\xae\x96\x8e\x02(\xf5\xa1\x82\xae\x83\xb6\xe2a\xdf\xd19\xcf?\n\x1e\x96\x00\x00\x00\xff\xffPK\x07\x08\xf5;U|v\x00\x00\x00\x97\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00splice.tgoUT\x05\x00\x01w@X\\t\x90\xbfn\xc20\x10\xc6w?\xc57\xa1 \xc0\xc0\x8a\nS\x17\x96.\x1d\xab\x0e.\\\xb0%\xc7A\xf6\x05\x86\x90w\xaf\xec\xd8%\x0c\xdd\x9c\xbb\xef\xcf\xef\"\xd6k|^\xad9\x11<5\xed\x8d\x02\\\xd7\xbc\x93%&\x18\xa6&\xa0\xf6m\x03\xd6\x84\x10e\xd1\x10\x8c;\x11\x02+\xcf\x12\xc7z\xea\x08Xma\x18wc-\xce\xe3PY\x1b]c\x9a\xaa\x99\xfc\xc4\x9b^\xd1\xa7\xcdE\x93\x07k\xe5b[\xeaI\\\x96\xdc\x855Z\x0f\xdb\xde\x8bb\x93\x88\xee\xba\xb5\x13\xaeT\xfa\x13/\xe1\xce;:KQw\xee\x84\xca\xa0\xef\xe5\x87jh\x18\x8eL~\x9e/\xaeR\xf7r\x8a\xefx\xfe\xaaE/\x80\x9b\xf2\xf0\x14:\xcb\xafK\x81\x02\xb7\xdb\xc7We\xe6\x020\xe5\xa8\xc3>\xafW[<\x1ey\xf8\x86M\xcaD\xa6\x84\x11\xc0 \x04J\xc3\x1e\xeaz%w\xae\xc6\xef%\xcc\xd7.Y\xbf\xa5\x949\xffI|\x88\xff{6{N\x16\xa5&\x93\x95\xae\xff\xb2\x93|\xf1g\xdf\x95\x96\x8c\x94\x10G\xb5\x18\xc4o\x00\x00\x00\xff\xffPK\x07\x08\xc3\x85\xd7\xfb\x12\x01\x00\x00.\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe1lDN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x08\x00	\x00type.tgoUT\x05\x00\x01w@X\\*\xa9,HU\xa8\xae\xd6\xf3K\xccM\xad\xad\xf5,I-R\x88\x8e\xad\xae\xd6\x0b\xa9,H\xad\xad\xe5\xe2J+\xcdKV\xf0K-GQ\xa2\x91Y\x92\x9a[\xac\xa0\xa7\xa7\x07W\xa9\x89fH5\x97\x82BQjIiQ\x9e\x02\x16\xad\x9a\\\xb5\\\x80\x00\x00\x00\xff\xffPK\x07\x08\xcd\x96<\x95Q\x00\x00\x00z\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xc4mDN\xa3\xf2L\x98v\x00\x00\x00\x97\x00\x00\x00\x07\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00all.tgoUT\x05\x00\x01!BX\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xf1\xda\xe8\x10\xcc\x00\x00\x00+\x01\x00\x00\x0e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb4\x00\x00\x00chan_array.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xff&\xd8\xaa\xf3\x00\x00\x00\xb0\x01\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc5\x01\x00\x00chan_concat.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xe9,\xa9\xac\x9d\x00\x00\x00\xdc\x00\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xfe\x02\x00\x00chan_filter.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDNq\x18<\xe4\x80\x00\x00\x00\xe6\x00\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe1\x03\x00\x00chan_foreach.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xcfF\xf1cI\x01\x00\x00$\x03\x00\x00\x0c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa8\x04\x00\x00chan_map.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xc7\xa7=\xe7\x06\x01\x00\x00\x16\x02\x00\x00\x14\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x814\x06\x00\x00chan_map_results.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xa5\x04\xb4\x82\xfe\x00\x00\x007\x02\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x85\x07\x00\x00chan_reduce.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xb6k\x13\x0b+\x00\x00\x00'\x00\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc9\x08\x00\x00chan_type.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDNm\x9dUrJ\x00\x00\x00]\x00\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x818	\x00\x00concat.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xb0\xc9m;\x93\x00\x00\x00\xdd\x00\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc3	\x00\x00filter.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xcf\xd0m\xe8\x86\x00\x00\x00\xbe\x00\x00\x00\x08\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x97\n\x00\x00find.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xc3>iGb\x00\x00\x00l\x00\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\\\x0b\x00\x00foreach.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xa8\x9a\xf2\x07=\x00\x00\x00A\x00\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x0c\x00\x00imports.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xc3\xe3\xde	\x08\x01\x00\x00x\x02\x00\x00\x07\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x7f\x0c\x00\x00map.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDNX\x97 |\xe1\x00\x00\x00\xa5\x01\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc5\x0d\x00\x00map_results.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xd7\xb8\xe1\x1d\xba\x00\x00\x00:\x01\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xec\x0e\x00\x00reduce.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN[\xe5\xd4a\x85\x00\x00\x00\xa6\x00\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe7\x0f\x00\x00reverse.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xf5;U|v\x00\x00\x00\x97\x00\x00\x00\x08\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xae\x10\x00\x00some.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xc3\x85\xd7\xfb\x12\x01\x00\x00.\x02\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81c\x11\x00\x00splice.tgoUT\x05\x00\x01w@X\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe1lDN\xcd\x96<\x95Q\x00\x00\x00z\x00\x00\x00\x08\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb6\x12\x00\x00type.tgoUT\x05\x00\x01w@X\\PK\x05\x06\x00\x00\x00\x00\x15\x00\x15\x00y\x05\x00\x00F\x13\x00\x00\x00\x00"
+	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x07\x00	\x00all.tgoUT\x05\x00\x01\x80Cm8,\xc9\xbd\nB1\x0c\xc5\xf1\xbdOq\xdcZ\x90\xfb\x00\x82\x83\xa3\x8b\x93\xbbTI\xa4\xd0\x9bJl\x07	yw\xb9\x1f\xc39\xc3\xff\x17x\xc8\x0b\xb1\xc0l\xba\xe5\x99\xdc\xaf\x9d4\xe1Rkd\xc1\xa2\xd1l\xba\xff>\xe4\x9e\xf0l\xadn\x0f\x0b\x007\xc5\xe3\x88\xd2i\xc6\xe9\x0c\xcd\xf2&\x94\x95\x80\xc28\xb0\xc4\x05\xd3\x9e\x00\xa5>T\xc0\xb9~iM\x1e\xb6\xed\xd0uP\xf0\xf0\x1f\x00PK\x07\x08\xa3\xf2L\x98s\x00\x00\x00\x97\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0e\x00	\x00chan_array.tgoUT\x05\x00\x01\x80Cm8L\x8f?K\xc4@\x10\xc5\xeb\x99O\xf1\xb8j\x174`+^q(\x88\x8d\x95`!\x16K2\xd9\x0b\x9e\x9b0\xd9M\x08\xcb~wI\xee<\xeeu\xf3\x87\xf7{\x8f\xdb\x14j\x98\x0e9W\xef\xeeWJy>\xba\xf0\x16E-\x0e\xaan1M\x1f\x04\xf5\xd1\x05\x8cQS\x1ds\xb1\xf8\xfa\xce\xb9\xfaX\x06)\x05\x99ir\n\xc3D*c:\xc5\xdb+\x13\xcd\x1e\xabr\x1e~<v\xe3\x12\xea])\xd5\xa7\xeb\xe2\xab\xf6i`\xb2\xcc4\xfb\xea\xd04\xe6\xc12\xf9\x1ek&c\x91\x99\xa8\xed\x15\x13\x1e\xf7P\x17\xbc\xa0\xdb\x96\xff\xa0=\xdc0Hh\xccy\xbe\xc3d\x99\xe8\x0c\xad^\xfa \xc62\x15\xb3\x02\x1aiEo\x8d\xb7ZO\xf7\xd7R\xb9\\^q\xd1\xec\xb7\x90\xab\x85JL\x1a\xa02\xa6S\xe4\xc2\x7f\x03\x00PK\x07\x08\xce\xcc\xe2\xdb\xd5\x00\x00\x005\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00chan_concat.tgoUT\x05\x00\x01\x80Cm8l\x90AKC1\x10\x84\xcf\x9b_1\xf4\x94@\x0dx\x15{\x90\n\xe2\xc5\x93\xe0AD\x964M\x1f\xb5\xc9#/i)!\xff]\x12-\x15\x9e\x97@\x96ovfv\x9b\xbd\x81\x1cP\x8a~\xe1\x83\xadu\xbdc\xff\x9clTX\x07o8I\x8en\x82\xd6\xfa\x1fb6B\x11t\xe4\x08)\x88BN\x00V8\xf0\xdeJ\xb3c\xdf\xf0\xd7\xf3hkU\x82\xe8\xe4\x00\xa0\x94q\xef\xb0\x98\xce\xde,j\xd5o<\xa4\xa7\x18\xf2(\x88\x9ad\xc2\n\xef\x1f3\x9b2TAJ\x08\xda\x86\x88\xcf%\x18w+D\xf6\xce\xa2\xa7-\x7f\xe4<\x8e\xd6oz\x80i	V\x82\xeaUh\xae\xc2\x0et\xe5\xc9\xe9\x87\xcdF\xde\xb6\x94.\xa0]H\x0e~^Vu\xbag8^\xf74R\x10\xfd\xf4\xbf\xbf\xc1\xb1}j{NN?\x06oe\xdb[\xa5\xf9MrqP\x17\xefv\x83\xce\x98\xaf0Y\x19rj\xa4lu\xa3M9z\x84\x9cD\x15\xdf\x03\x00PK\x07\x08\xa2\x93\xe01\xf9\x00\x00\x00\xba\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00chan_filter.tgoUT\x05\x00\x01\x80Cm8d\x8c\xb1\x8a\xc30\x0c\x86g\xeb)\xfe\xd1\x1e.\x0fp\xdcM\x07\x07]:\xf5\x05\xdc '\xa6\x89U\\;P\x8c\xde\xbd\xb8)t\xa8@\x83\xc4\xf7}\x14j\x1aa#Z\x1b\x8e~e\xd5\xbf\xd9\xa7C\xe1\xec\xf0\x1f\x97\xc2\xd9\x86\x84\x0e\xd9\xd6\x86\xd3\xfd\xca\xaa\x0eg\x91\xc5}*h\x84\xd7H-\xf8\xfe\xc5\xea/l\xc7\xd9'\xbcm\"3\xc9\x9ethdL\x90\x8c\xad\xd3\xd9\xa7\x89\x11\x9fO\x13\x03B\xb2\xdb\x8e\x18\xd3\x83?_\xd8\xfa\xa1\xb4\xef\xb8\xc8\x8d\xad\xd4\xe2\xc8\xa8\xed\xe1\xcc\xa5\xe6\x04\xa9\x85\x94\x1e\x03\x00PK\x07\x08\xe9,\xa9\xac\x9a\x00\x00\x00\xdc\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00chan_foreach.tgoUT\x05\x00\x01\x80Cm8t\xcbA\n\xc20\x10\x85\xe1}N\xf1\x96	-\x1e@p%\nn\\y\x81P\x926\x0b_d\x88\x01	sw)\xda\"\xa8\xff\xf2\xcd7&\xde9\xc0&\xb4\xb69\xfbkP\xddO\x9e\xa7\x12\xc4\xe1\x98\xe5\xe0\x87\xc9FbV6\xb1\xf4\xb3\xbb<nA\xd594\x83w\xd5\x0b\x88\xc4\xb2.c~=}\xaa\xa5\x98\x05\x15\xdb\x1d\xc4s\x0cH?\xc8R\xa4e\x8f\xea\xfe\x02v\xdd\xd7M\xd7E\xad3j\xccs\x00PK\x07\x08q\x18<\xe4|\x00\x00\x00\xe6\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0c\x00	\x00chan_map.tgoUT\x05\x00\x01\x80Cm8|\x91\xc1\x12\x9a0\x10\x86\xcf\xd9\xa7\xd8rJF\xf4\x01Z=uz\xe8A\x0f\x1d\xef\x9d\x14\x17\xcd\xa0	\xb3\x04\xaa\x93\xc9\xbbw\x02EEF\xf7\x04a\xf3\xed\xf2\x7f\xe0o5a\x08\xab\x9d\xbeP\x8c\xdfO\xdanu\xfd\x8b\x9a\xf6\xecq\xbd,N\xda\xa2\xb1\x9e\xb8\xd4\x05\x85\x08P\xb6\xb6@i\xa6W~zb\x85[]\xcb\xd2b\xea\x90\xc6\xfa<\xf5\xeco5\xc5\xa8\x9e\x19\xea\xdd\xb8\x00\xc2\xb5\x1e\xbfn\xf0\xa2+\x92\xaf\xb3\x15\x808\xba\x01\xaf0\x00\xbeT\xa7\x19\xcd\xe1\x9aF\x81\x10\xa5c\xec\x12\x8b\xb5=\x12\x9aD\x17=\x7f\xbd\xc4\xd2Js\xb8\xe6\xd8\xa9\x19e,s\xb8.\x16 D\x04!\x8a\xb3kH\xba\xd6+\x10Q\xa6=\x98|\xcb\x16]\xeb!\x02\xa4\xc9?\x98'\xbf\xb5w\xf7W\xdc`\x08uu\xc4\x8c\x98\x1d7Y\x8c\xab\x1d\xfd\x95Y\xa1\xadu\x1e\x0bg;b\xff.\x16\xef\xb0\x8f\"\x04j\n]\x13\xfe\x0f5S\xa3\x0d~sUa\x12#\x15\xca\xc9\xf7t\x98\x0f\xcc~\xa1\xe70g\x02\x1e\x0e\xefQ\x11\xf3T\xd2@y\xd13S\xc0\xfd\xa10%\xfe\xce\xd1U	\xd1\xad\xe4\x83\xff\x0d\xbf\xb8j\xe8\x11i\xc4z\xf9)\xd4\xbe\xed\x0f\x93\xae\xd2S|\x92;\x81\xf6\x06\xc7\xc5\xc7\x1a|\x12\xf3\\\xff'\xd59\x123D\x80\x7f\x03\x00PK\x07\x08\x14\xed>\x8aV\x01\x00\x005\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x14\x00	\x00chan_map_results.tgoUT\x05\x00\x01\x80Cm8|P\xc1N\xc30\x0c='_a\xaa\x1d\x12i\xeb\x07\xc0vB\x1c\xd9\x01\xed\x8eB\xf16\xd4-\xae\xdc\xb4\x08Y\xfew\x946Ll\x12\xf8\x94\xd8\xef=??\x91E\x0cg\x84\xfb\x0d\xd4\xdbpFU\x11\x0e\xf1\x80\xb0\xe0\xa9\xf9\x82\xfdpJ\xbd\xaa\x1d\x03\xc3\x13sa\xa8>\x1eC\xdc\x91H\xe1\xc1\x06D\xba\xf6\x00\x152\x13\xf7\x95j\xbd\xc5OW5!FJ\xd0P\x1c\x91\x13\\\xf1\x9fC7/\x80D\xd0\x1cC\x04\x11\xec\x9b\xd0!\xd4\xbb\xaf\x0eU+o\xed~\x88\x0d8\xfe\x83\xea\xe1\x97\x0b\xe7\xc1\x15\x9d\"\xb0\x9cu'S\x1e\xc4B)\x1aR>\xf0\x1cZ\xbca\xf8\x0b\x06\x99\xaf1\xb3\x8a\xb5\xe6@\x90]\xb9\xach\xcc\x9e\x18\xc6\x8c\x9c\xa3\xe3\xa9i>\xf6\xf0\xba\x04j\xf3`\xac\xdd\xc5\x91\x7f\x80;jg\x8c\xc9+\xd6\xab\xff\x82\x9d`o\x8c\xa1\xcd\xaf\xe9\x9b\xbd\xafW\xd7\xa2\xd6\x18\xbd\x18\xff\xa9\xe6D=:d\xf6\xf6fRF4$o\x8d\xba|\x13c\x1a8\xe6`\x96\xf9P\xb0*\x82\xf1]\xd5\xda\xef\x01\x00PK\x07\x08s\x0e\x1c\x82\x14\x01\x00\x00'\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00chan_reduce.tgoUT\x05\x00\x01\x80Cm8l\x901n\xf30\x0c\x85w\x9d\x82C\x06	\xf1\xff\x1f\xa0h\xa6N]:\x14\xbd\x80 \xd3\x89\xd0\x98\x0eh\xa9H@\xf0\xee\x85l\xd5n\xe2r\xb2\xdf{\xfa\xf8@\x91\x1d\xf9\x1e\xe1\xe9\x00\xff\xdf|\x8f\xaa\"\xbbt\xbb\xcc\xca\xc7\xed\x82\xaaF\x84=\x1d\x11v<\xa9\xef\xd8\xe6\x80<\xaa\x9a.S\x00\x1b\xa1bT_N\x9e^\x13\xb2\x839%R\xb1\xb6#(i\x1b23R\x82\xbaG\xb5\x01\x1f\x02\x88\xd4m\x0dDj\xf1\n\x91\x92\xbbWc\x8a\xfe\xbcJ\x0e\xc2\xc9\xd3\xfa\x0fb\xa0\xce\x90Si\xda\xfbO\xb4\xf7!\xb7d\x18\xc7|\x9eb\x95\xbc8\xcb\xc7q\x98+\xbb_\xe8\x9f\xf9\xf2\x0c\xb1\x9djn\xbcn`\x88	\xfb\x02\x9f/\x17\xff <\x149@G\xb6\xbcjj\xb5\xa6\xf0\x9dyH/\x13\xdb\xeb~\xbfq\xd5l\xa4r\x8c\xe7\x7f\x15\xbaq\xc3y\x18\xd1\x0e9\xad\x9b\xd4\xba\x95\xc2\x982S\x81\x185\"H\xad\xaa1\xdf\x03\x00PK\x07\x08\xa5\x04\xb4\x82\xfb\x00\x00\x007\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x00chan_type.tgoUT\x05\x00\x01\x80Cm8\x00A\x00\xbe\xfftype {{.Name}}ChanIter {{if .IsRecvOnly}}<-{{end}}chan {{.Type}}\n\x03\x00PK\x07\x08\xce\xd6\x1c\xfeH\x00\x00\x00A\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00concat.tgoUT\x05\x00\x01\x80Cm8\x00]\x00\xa2\xff\nfunc (i {{.Name}}Iter) Concat(i2 {{.Name}}Iter) {{.Name}}Iter {\n  return append(i, i2...)\n}\n\x03\x00PK\x07\x08m\x9dUrd\x00\x00\x00]\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00filter.tgoUT\x05\x00\x01\x80Cm8T\x8e\xc1\xaa\xc20\x10E\xf7\xf9\x8a\xbbL\xe0\xd1\x0fx\xd0\xad\xe0\xc6\x95;\x11\x89:\x91@:-\xd3\x89 !\xff.iU\xe8b\x163s\xee\xe1\x9a\x90\xf9\x06\x1bQJw\xf0\x03\xd5\xbaW\x12\x87]LJb\x03\xa3\x01\xb6\x94\xee\xf8\x9a\xa8V\x87\xeb8&\xb7\xc5Q\x0c\xf0\xf4\x02\xa19'\xc5\xe9\xfc\xe3\x0d\x10F\xc1\xe5\x0fQi\xc0\x7f\x0f\xf1\xfc \xc4%\x03\xc4\x80\xc0\xb6\xfd\xdc\xe7\x82\xaf\xa5\x87\x9f&\xe2\xbb]\xf7\xd5\xe0\x16\xa6i\xdb\x08i\x16\xde\x96\xb1BsN\xeaL5\xef\x01\x00PK\x07\x08\xb0\xc9m;\x93\x00\x00\x00\xdd\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x08\x00	\x00find.tgoUT\x05\x00\x01\x80Cm8D\xcc1\x8a\xc30\x10F\xe1^\xa7x\xa5\x04Z\xc3\xb6\x0b\xdb.l\x93*\x17p\x92Q\x18\x88\xa50\xc8\x81X\xe8\xee\xc1&8\xed\xfbf~\x97\xe6|\xc6+\xad\x0d\x87q\x92\xde\xff\xabX\xe0O\xf3\xc5\xa7\xcc\xca\xbe\xb5\xe1\xf8\xbcK\xef\x81S)\xb7\xc0\xa7D4\xd7@s\xf0\x18\x8dE\xac\xb0\xa3\x83T\x0c\x8dh\x95\x89\x9f_l\xccWA\xb7{\xd0D\xca~\xb5\xf0.`Rg\xcb\xdbCD\xb7\xb8\xeet\xb7\xd3\"V\"_\xdf\xae\xbb\xd7\x00PK\x07\x08\xcf\xd0m\xe8\x85\x00\x00\x00\xbe\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00foreach.tgoUT\x05\x00\x01\x80Cm8\x00l\x00\x93\xff\nfunc (i {{.Name}}Iter) ForEach(fn func(int, {{.Type}})) {\n  for n, item := range i {\n    fn(n, item)\n  }\n}\n\x03\x00PK\x07\x08\xc3>iGs\x00\x00\x00l\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00imports.tgoUT\x05\x00\x01\x80Cm8\x00m\x00\x92\xff{{if .}}import (\n{{range $imp := .}}  {{if $imp.Alias}}{{$imp.Alias}} {{end}}\"{{$imp.Path}}\"\n{{end}}){{end}}\n\x03\x00PK\x07\x08\xf4\xaaQVt\x00\x00\x00m\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x07\x00	\x00map.tgoUT\x05\x00\x01\x80Cm8|\x90\xb1n\"1\x10\x86{?\xc5\x7f[\xd9\x92\xc5\x03\xdc\x89\xf2\x8a+\xa08\xd1!\x14Y\x9bYd\x01ck0D\xc8\xf2\xbbG&f\x93M\xb2\xe9v\xf5\xcf\xe7\x7f\xe6S\xe9\x16	9/\xd6\xeeD\xa5\xfcK$+\x17\xff\xd3\xf9rL\xd8\xee<'\x92\xc1\xf5\x94\x8bR\xc3\x85{h?\x9d6X\xb9\xa8\x07FM\xb5\xe7dk\xbe\xb9E*\xc5\xe0\x03o\xe6Z\xb2\x02\xaeN \xdf\x95\x02C\x10\xb0\x85Ot\xc2\xef%\xc4\xf1\x9e\xe0\xef\x14\x1e\xcc\x12.F\xe2g\xfd\xf6o1\xb0n\x8c1\n\xa8\xef\x08\xa5\x8bp#TQ\xaav\xfe\x15\x19\xb7\xda\x84\xf1\x13K\xe4\x1c\x0f{t$\x12\xe4\xdc\x95\xb2X\xd3\x8b\xeez\xc7\x1c\x12\xfa\xc0W\x924wQ\n\xd8\xeer\xa6s\xef\"\xa1\xc9\xe8\xcc\xc3\xa0\xccp\x06U\xbf6\xd0\x93\xdc\xe2\xbe\x84\xf9*j\x14\xdd4=Y\xf8wG\xd2\x1c\xf9\xa1\x06\xe1P\x13\xbf\xd0#d\xfe\xe0W8\xb4\xa1\xd1\x0f\xfb\xa3\x9d\xd3r\x9f,?\x8a\x9f\x14|R?\xb9\xaa\x11\xc6\x82\xfdQ\x15\xf5:\x00PK\x07\x08\xe5\x18\x94\x1b\x14\x01\x00\x00\x89\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00map_results.tgoUT\x05\x00\x01\x80Cm8t\x90\xc1j\xeb0\x10E\xf7\xfa\x8a\xfbL\x16\x12\x18\x7f\xc0+Yv\xd1E\xb3(\xd9\x95R\x843\x0e\xc6\x8e$\xc6rJ\x19\xe6\xdf\x8bm5\xa4\x8bl\x0c\xd6\x9csuG\"\xbb\xe0/\x84\xff{4\x07\x7f!U\x11\xf6\xe1L\xd8\xf1z\xf8F\xd3<\xe6I\xd5\\=\xe3\x99\xb9\x18\xaa\xc7(R\x1c\xec!\x92\x863*b\x8e<U\xaa\xcd\x81\xbel\xd5\xfa\x10bF\x1b\xc3\x958\xe3\xe6\xbed\xe2W\x9f\xb6p\xe4\x88\xf7\x0f\x11\x9aZ\x9f\x08\xcd\xf1;\x91j\xe5\x8c\xe9\xe6\xd0\xc2\xf2\x03\xcf\xe1\xae\x82u\xb0KH\xb1k\xacM\x1c\xc4\x00Ks^\x15\xdc!\x06\xe8\"\xe3\xb3F\xbfl\xbam\xcd\xab\x00\xf4\xdd2\x88\xc32\xe9\x1b{\x93\xdc\x13\xfe\xc5\xa1@\x00S\x9e9 \xf4c\xfd\xe8mVr\xfb\x96\x0e{\xf8\x94(\x9c\xec\xf6_\xff\xbd\xc0\x99\x0d/\xd1\xbfL\xe8G\xa3\"\x14N\xaa\xe6g\x00PK\x07\x08,i\xdf\xc1\xec\x00\x00\x00\xb6\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00reduce.tgoUT\x05\x00\x01\x80Cm8T\x8fAj\xc30\x10E\xf7s\x8a\xbf\xf0\xc2\x06\xd1\x03\x14r\x80n\xba(\xbd\x80\x90GE\x10O\xc3D*	\xc3\xdc\xbd(Vq\xb3}\xf3\xfe\xff\x92\xd9$qc\xbc\x9e\xf0\xf2\x1e7v7\x9b\xea\xfd\xb2\x93\xcf\xfb\x85\xdd\xc9L\xa3|1&}\xd0\x0f^[b\xbd\xbaSn\x920\x17\x8c\x1a\xf7\xb7\xca\xba`7\xccF\xe5\x9c\x05\xdd\x9cSSe\xa9\x18\x1b\xee\x011%\x98\x8d\xa5\x80\"+\xdfP\xa4.\xcf\xb4\xd4\x12\xcf\x07\xfaw\x85\x11\xf0\x13\x15\xca\xd7v\xae8\xfd\xd9\x04\xe4oEYo\x01\xa5\xf2\xd6\xdf\xbe\xff\xa3<28\x12Y\xe6n\x84AB\x0f-\x048u\xa96\x95q!'3\x96\xd5\x9d~\x07\x00PK\x07\x08\xd7\xb8\xe1\x1d\xb7\x00\x00\x00:\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00reverse.tgoUT\x05\x00\x01\x80Cm8T\xcc\xb1\n\xc20\x14\x85\xe1\xfd>\xc5\x19\x13\xb0EWK\xdd]\x1c\xc4\xadt\x08z\x0b	1\x86\x9b\xa4 !\xef.*\x0e\x8e\x87\xc3\xf7\xd3R\xc2\x15\xca\xa2\xd6\xfed\xee\xdc\xda1\xb3h\x9cyeI\xac\xf4\xff\x81J\xc0j\x04\xc2\xa9\xf8\x8ci\xae\xb5\xbf<#\xb7F\xc0\xf2\x108\xecGx\x0e\xca\xean7\xc0\xe10b;\xc0u\xdd\xc7\xe2'G\x98\x189\xdc\xd4wo`'7k\x02\xde!\xe1\\$@8\x15\x9f\xa9\xd1k\x00PK\x07\x08[\xe5\xd4a\x82\x00\x00\x00\xa6\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x08\x00	\x00some.tgoUT\x05\x00\x01\x80Cm8,\xc9=\n\x021\x10\xc5\xf1>\xa7xe\x02\xb2\x07\x10<\x80\x8d\x8d\xf6\x12eF\x02\x9b\x89\x8cI!\xc3\xdc}\xd9\x8f\xe2\xbd\xe2\xff\x0b<\xe4\x8dX`6\xddr%\xf7k'M\xb8\xb7J\x91\x05+G\xb3\xe9\xf1\xff\x92{\xc2\xab\xb5y\x7fX\x00\xb8)\x9e'\x94N\x15\xe7\x0b4\xcb\x87P6\x02\n\x83%\xae\x96\x8e\x02(\xf5\xa1\x82\xae\x83\xb6\xe2a\xdf\xd19\xcf?\n\x1e\x96\x01\x00PK\x07\x08\xf5;U|s\x00\x00\x00\x97\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00splice.tgoUT\x05\x00\x01\x80Cm8t\x90\xbfn\xc20\x10\xc6w?\xc57\xa1  \xc0\x8a\nS\x17\x96.\x1d\xab\x0e.\\\xf0I\x8e\x83\xec\x0b\x0c\x90w\xafl\xec\x12\x86n\xce\xdd\xf7\xe7wQ\xcb%>\xcf\x96\x0f\x04Omw\xa1\x00\xd7\xb7\xefdI\x08,\xd4\x064\xbek!\x86\x10\xa2,\x1a\x02\xbb\x03!\x88\xf6Rc\xdf\x8c\x1d\x01\x8b5Xpekq|\xc4hk\xa3\xeb\x91\xa6\x1b!?\xf2\xa6\x178\xc0\xf0\xc9\x90\x87\x18\xedb[t\xa4BXr'1\xe8<lw-\x8aU\xd4\xe0j:;\xe2J\xa5?\xf1\x12\xe9\xbd\xa3c\xad\x9a\xde\x1dP1n\xb7\xfaC\xb74\x0c{!?\xcd\x17W\xa9{>\xc6w2}\xd5\xe2\xa6\x80\x8b\xf6\xf0\x14z+\xafK\x85\x02\xb7\xd9\xc6W\xc5S\x05p9j\xb7\xcd\xeb\xc5\x1a\xf7{\x1e\xbea\x952\x91)\xc1\n\x18\x94Bi\xd8B\x9f\xcf\xe4\x8e\xd5\xe3{\x0e\xfe\xda$\xebw]\xd79\xffI\xbc\x8b\xff{2yNf\xa5&\x93\x95\xae\xff\xb2\x93|\xf6g\xdf\x94\x96\x8c\x94\x10=\x85\xde\x8a\x1a\xd4\xef\x00PK\x07\x08\xc3\x85\xd7\xfb\x11\x01\x00\x00.\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x08\x00	\x00type.tgoUT\x05\x00\x01\x80Cm8\x00z\x00\x85\xfftype {{.Name}}Iter []{{.Type}}\n\nfunc New{{.Name}}Iter(items ...{{.Type}}) {{.Name}}Iter {\n  return {{.Name}}Iter(items)\n}\n\x03\x00PK\x07\x08\xcd\x96<\x95\x81\x00\x00\x00z\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xa3\xf2L\x98s\x00\x00\x00\x97\x00\x00\x00\x07\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x00\x00\x00\x00all.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xce\xcc\xe2\xdb\xd5\x00\x00\x005\x01\x00\x00\x0e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xb1\x00\x00\x00chan_array.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xa2\x93\xe01\xf9\x00\x00\x00\xba\x01\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xcb\x01\x00\x00chan_concat.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xe9,\xa9\xac\x9a\x00\x00\x00\xdc\x00\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\n\x03\x00\x00chan_filter.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(q\x18<\xe4|\x00\x00\x00\xe6\x00\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xea\x03\x00\x00chan_foreach.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x14\xed>\x8aV\x01\x00\x005\x03\x00\x00\x0c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xad\x04\x00\x00chan_map.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(s\x0e\x1c\x82\x14\x01\x00\x00'\x02\x00\x00\x14\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81F\x06\x00\x00chan_map_results.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xa5\x04\xb4\x82\xfb\x00\x00\x007\x02\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xa5\x07\x00\x00chan_reduce.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xce\xd6\x1c\xfeH\x00\x00\x00A\x00\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xe6\x08\x00\x00chan_type.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(m\x9dUrd\x00\x00\x00]\x00\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81r	\x00\x00concat.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xb0\xc9m;\x93\x00\x00\x00\xdd\x00\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x17\n\x00\x00filter.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xcf\xd0m\xe8\x85\x00\x00\x00\xbe\x00\x00\x00\x08\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xeb\n\x00\x00find.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xc3>iGs\x00\x00\x00l\x00\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xaf\x0b\x00\x00foreach.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xf4\xaaQVt\x00\x00\x00m\x00\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81d\x0c\x00\x00imports.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xe5\x18\x94\x1b\x14\x01\x00\x00\x89\x02\x00\x00\x07\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x1a\x0d\x00\x00map.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(,i\xdf\xc1\xec\x00\x00\x00\xb6\x01\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81l\x0e\x00\x00map_results.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xd7\xb8\xe1\x1d\xb7\x00\x00\x00:\x01\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x9e\x0f\x00\x00reduce.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!([\xe5\xd4a\x82\x00\x00\x00\xa6\x00\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x96\x10\x00\x00reverse.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xf5;U|s\x00\x00\x00\x97\x00\x00\x00\x08\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81Z\x11\x00\x00some.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xc3\x85\xd7\xfb\x11\x01\x00\x00.\x02\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x0c\x12\x00\x00splice.tgoUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xcd\x96<\x95\x81\x00\x00\x00z\x00\x00\x00\x08\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81^\x13\x00\x00type.tgoUT\x05\x00\x01\x80Cm8PK\x05\x06\x00\x00\x00\x00\x15\x00\x15\x00y\x05\x00\x00\x1e\x14\x00\x00\x00\x00"
 	fs.Register(data)
 }
@@ -4,8 +4,18 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"text/template"
+	"unicode"
+	"unicode/utf8"
 
+	// the templates are bundled in the statik package, which has to be
+	// initialized before they are loaded
+	_ "github.com/erizocosmico/go-itergen/statik"
 	"github.com/rakyll/statik/fs"
 )
 
@@ -17,30 +27,31 @@ var templateFs = func() http.FileSystem {
 	return fs
 }()
 
-var tpls = map[string]*template.Template{
-	"type":        loadTemplate("type"),
-	"imports":     loadTemplate("imports"),
-	"map":         loadTemplate("map"),
-	"map_results": loadTemplate("map_results"),
-	"filter":      loadTemplate("filter"),
-	"some":        loadTemplate("some"),
-	"all":         loadTemplate("all"),
-	"foreach":     loadTemplate("foreach"),
-	"concat":      loadTemplate("concat"),
-	"find":        loadTemplate("find"),
-	"reverse":     loadTemplate("reverse"),
-	"splice":      loadTemplate("splice"),
-	"reduce":      loadTemplate("reduce"),
-
-	"chan_type":        loadTemplate("chan_type"),
-	"chan_concat":      loadTemplate("chan_concat"),
-	"chan_filter":      loadTemplate("chan_filter"),
-	"chan_map":         loadTemplate("chan_map"),
-	"chan_map_results": loadTemplate("chan_map_results"),
-	"chan_imports":     loadTemplate("imports"),
-	"chan_foreach":     loadTemplate("chan_foreach"),
-	"chan_reduce":      loadTemplate("chan_reduce"),
-	"chan_array":       loadTemplate("chan_array"),
+// funcs are the functions available to all the templates.
+var funcs = template.FuncMap{
+	"escape":     escape,
+	"zero":       zero,
+	"lowerFirst": lowerFirst,
+	"plural":     plural,
+	// pkg returns the name used to refer to a standard library package in
+	// the generated code, which is overridden for every generator.
+	"pkg": func(path string) string { return path },
+	// qualified returns the given identifier of the package with the given
+	// import path as referred to in the generated code, which is overridden
+	// for every generator.
+	"qualified": func(path, name string) string { return name },
+}
+
+// tpls are the built-in templates, by name. They are loaded in init, as the
+// names of the templates of the operations are taken from operations, whose
+// code refers to them.
+var tpls map[string]*template.Template
+
+func init() {
+	tpls = make(map[string]*template.Template)
+	for _, name := range templateNames() {
+		tpls[name] = loadTemplate(name)
+	}
 }
 
 const (
@@ -48,16 +59,11 @@ const (
 	importsTpl    = "imports"
 	mapTpl        = "map"
 	mapResultsTpl = "map_results"
-	filterTpl     = "filter"
-	someTpl       = "some"
-	allTpl        = "all"
-	forEachTpl    = "foreach"
-	concatTpl     = "concat"
-	findTpl       = "find"
-	reverseTpl    = "reverse"
-	spliceTpl     = "splice"
 	reduceTpl     = "reduce"
-	arrayTpl      = "array"
+
+	// chanPrefix is the prefix of the names of the templates for channel
+	// iterables.
+	chanPrefix = "chan_"
 )
 
 func loadTemplateText(name string) string {
@@ -76,12 +82,75 @@ func loadTemplateText(name string) string {
 
 func loadTemplate(name string) *template.Template {
 	text := loadTemplateText(name)
-	return template.Must(template.New(name).Parse(text))
+	return template.Must(template.New(name).Funcs(funcs).Parse(text))
+}
+
+// zero returns the expression of the zero value of the given type, which is
+// a TypeDef, the data of the templates of operations or a type expression.
+func zero(t interface{}) (string, error) {
+	if data, ok := t.(pluginData); ok {
+		t = data.TypeDef
+	}
+
+	switch t := t.(type) {
+	case TypeDef:
+		if t.zero != "" {
+			return t.zero, nil
+		}
+		return "*new(" + t.Type + ")", nil
+	case string:
+		return "*new(" + t + ")", nil
+	default:
+		return "", fmt.Errorf("zero expects a TypeDef or a type, got %T", t)
+	}
 }
 
-func getTemplate(name string, isChan bool) (*template.Template, error) {
-	if isChan {
-		name = "chan_" + name
+// lowerFirst returns the given identifier with its first letter in lower
+// case, so FooIter becomes fooIter.
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
+
+// plural returns the plural of the given English noun, so Item becomes Items
+// and Category becomes Categories.
+func plural(s string) string {
+	lower := strings.ToLower(s)
+	switch {
+	case s == "":
+		return s
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return s + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return s[:len(s)-1] + "ies"
+	default:
+		return s + "s"
+	}
+}
+
+// escape escapes the given text so it can be safely written inside a string
+// literal in the generated code.
+func escape(text string) string {
+	quoted := strconv.Quote(text)
+	return quoted[1 : len(quoted)-1]
+}
+
+// getTemplate returns the template with the given name for slice or channel
+// iterables, taking it from overrides if it is there. The templates for
+// channel iterables are prefixed with chan_, but the one of the imports,
+// which is shared by both.
+func getTemplate(name string, isChan bool, overrides map[string]*template.Template) (*template.Template, error) {
+	if isChan && name != importsTpl {
+		name = chanPrefix + name
+	}
+
+	if tpl, ok := overrides[name]; ok {
+		return tpl, nil
 	}
 
 	tpl, ok := tpls[name]
@@ -91,3 +160,97 @@ func getTemplate(name string, isChan bool) (*template.Template, error) {
 
 	return tpl, nil
 }
+
+// loadTemplateDir returns the templates in dir that override the built-in
+// ones, by template name. A template overrides the built-in one read from the
+// file with the same name. Templates starting with a front matter define
+// operations and are skipped, and any other template must be named after a
+// built-in one.
+func loadTemplateDir(dir string) (map[string]*template.Template, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("can not read the templates directory: %s", err)
+	}
+
+	overrides := make(map[string]*template.Template)
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".tgo" {
+			continue
+		}
+
+		path := filepath.Join(dir, f.Name())
+		text, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasPrefix(string(text), frontMatterDelim+"\n") {
+			continue
+		}
+
+		name := strings.TrimSuffix(f.Name(), ".tgo")
+		if _, ok := tpls[name]; !ok {
+			return nil, fmt.Errorf("%s is not named after a built-in template and has no front matter defining an operation, expecting one of %s.tgo", path, strings.Join(templateNames(), ".tgo, "))
+		}
+
+		tpl, err := template.New(path).Funcs(funcs).Parse(string(text))
+		if err != nil {
+			return nil, err
+		}
+
+		overrides[name] = tpl
+	}
+
+	return overrides, nil
+}
+
+// templateNames returns the sorted names of the built-in templates, which
+// are also the names of their files without the .tgo extension. Besides the
+// ones of the type, its imports and the results of map, every built-in
+// operation has a template named after it for each kind of iterable it
+// supports.
+func templateNames() []string {
+	names := []string{typeTpl, chanPrefix + typeTpl, importsTpl, mapResultsTpl, chanPrefix + mapResultsTpl}
+	for _, op := range operations {
+		if !op.IsBuiltin() {
+			continue
+		}
+
+		if op.Slice {
+			names = append(names, op.Name)
+		}
+		if op.Chan {
+			names = append(names, chanPrefix+op.Name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// DumpTemplates writes all the built-in templates to dir, which is created if
+// it does not exist, to be used as a starting point for the ones given with
+// --templates. It returns the written files. If force is false, nothing is
+// written if any of the files already exists.
+func DumpTemplates(dir string, force bool) ([]string, error) {
+	var files []string
+	for _, name := range templateNames() {
+		file := filepath.Join(dir, name+".tgo")
+		if _, err := os.Stat(file); err == nil && !force {
+			return nil, fmt.Errorf("%s already exists, use --force to overwrite it", file)
+		}
+		files = append(files, file)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	for i, name := range templateNames() {
+		if err := ioutil.WriteFile(files[i], []byte(loadTemplateText(name)), 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
@@ -1,6 +1,13 @@
 package generator
 
-import . "gopkg.in/check.v1"
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
 
 type TplSuite struct{}
 
@@ -23,3 +30,179 @@ func (s *TplSuite) TestLoadTemplate(c *C) {
 	t := loadTemplate("type")
 	c.Assert(t, Not(IsNil))
 }
+
+func (s *TplSuite) TestLoadTemplateDir(c *C) {
+	dir := c.MkDir()
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "imports.tgo"), []byte("// imports\n"), 0644), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "chan_map.tgo"), []byte("// chan map\n"), 0644), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "filter.tgo"), []byte("---\nname: keep\n---\n// keep\n"), 0644), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("# templates\n"), 0644), IsNil)
+
+	overrides, err := loadTemplateDir(dir)
+	c.Assert(err, IsNil)
+	c.Assert(overrides, HasLen, 2)
+	for _, name := range []string{"imports", "chan_map"} {
+		c.Assert(overrides[name], Not(IsNil), Commentf("template %s", name))
+	}
+
+	tpl, err := getTemplate("imports", true, overrides)
+	c.Assert(err, IsNil)
+	c.Assert(tpl, Equals, overrides["imports"])
+
+	tpl, err = getTemplate("map", true, overrides)
+	c.Assert(err, IsNil)
+	c.Assert(tpl, Equals, overrides["chan_map"])
+
+	tpl, err = getTemplate("map", false, overrides)
+	c.Assert(err, IsNil)
+	c.Assert(tpl, Equals, tpls["map"])
+
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "map.tgo"), []byte("{{.Name"), 0644), IsNil)
+	_, err = loadTemplateDir(dir)
+	c.Assert(err, ErrorMatches, "template: .*map.tgo:1: unclosed action")
+	c.Assert(os.Remove(filepath.Join(dir, "map.tgo")), IsNil)
+
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "filtr.tgo"), []byte("// filter\n"), 0644), IsNil)
+	_, err = loadTemplateDir(dir)
+	c.Assert(err, ErrorMatches, ".*filtr.tgo is not named after a built-in template and has no front matter defining an operation, expecting one of all.tgo, .*, type.tgo")
+
+	_, err = loadTemplateDir(filepath.Join(dir, "missing"))
+	c.Assert(err, ErrorMatches, "can not read the templates directory: .*")
+}
+
+func (s *TplSuite) TestTemplateNames(c *C) {
+	files, err := filepath.Glob(filepath.Join("templates", "*.tgo"))
+	c.Assert(err, IsNil)
+
+	var names []string
+	for _, file := range files {
+		names = append(names, strings.TrimSuffix(filepath.Base(file), ".tgo"))
+	}
+	c.Assert(templateNames(), DeepEquals, names)
+}
+
+func (s *TplSuite) TestDumpTemplates(c *C) {
+	dir := filepath.Join(c.MkDir(), "templates")
+	files, err := DumpTemplates(dir, false)
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, len(templateNames()))
+	c.Assert(files[0], Equals, filepath.Join(dir, "all.tgo"))
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "type.tgo"))
+	c.Assert(err, IsNil)
+	c.Assert(string(content), Equals, typeText)
+
+	_, err = DumpTemplates(dir, false)
+	c.Assert(err, ErrorMatches, ".*all.tgo already exists, use --force to overwrite it")
+
+	_, err = DumpTemplates(dir, true)
+	c.Assert(err, IsNil)
+
+	// the dumped templates generate the same code as the built-in ones
+	overrides, err := loadTemplateDir(dir)
+	c.Assert(err, IsNil)
+	c.Assert(overrides, HasLen, len(tpls))
+}
+
+func (s *TplSuite) TestGenerateWithTemplates(c *C) {
+	dir := c.MkDir()
+	text := "type {{.Name}}Iter []{{.Type}}\n\n// custom\n"
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "type.tgo"), []byte(text), 0644), IsNil)
+
+	g := New(Options{Type: "float64", Package: "foo", Filter: true, Templates: dir})
+	code, _, err := g.GenerateSource()
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Matches, "(?s).*--templates=.*type Float64Iter \\[\\]float64\n\n// custom\n\nfunc \\(i Float64Iter\\) Filter.*")
+
+	// operations named after a built-in template do not override it
+	keep := "---\nname: keep\n---\n// keep {{.Name}}\n"
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "filter.tgo"), []byte(keep), 0644), IsNil)
+	g = New(Options{Type: "float64", Package: "foo", Filter: true, Templates: dir, Plugins: map[string]map[string]string{"keep": nil}})
+	code, _, err = g.GenerateSource()
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Matches, "(?s).*func \\(i Float64Iter\\) Filter.*// keep Float64\n")
+
+	g = New(Options{Type: "float64", Package: "foo", Templates: filepath.Join(dir, "missing")})
+	_, _, err = g.GenerateSource()
+	c.Assert(err, ErrorMatches, `--templates ".*missing": can not read the templates directory: .*`)
+}
+
+func (s *TplSuite) TestFuncs(c *C) {
+	for _, tc := range [][2]string{
+		{"Item", "Items"},
+		{"Category", "Categories"},
+		{"Day", "Days"},
+		{"Bus", "Buses"},
+		{"Box", "Boxes"},
+		{"Match", "Matches"},
+		{"Float64", "Float64s"},
+		{"", ""},
+	} {
+		c.Assert(plural(tc[0]), Equals, tc[1])
+	}
+
+	c.Assert(lowerFirst("Float64Iter"), Equals, "float64Iter")
+	c.Assert(lowerFirst("Ñandu"), Equals, "ñandu")
+	c.Assert(lowerFirst(""), Equals, "")
+
+	z, err := zero(TypeDef{Type: "int", zero: "0"})
+	c.Assert(err, IsNil)
+	c.Assert(z, Equals, "0")
+
+	z, err = zero(TypeDef{Type: "foo.Bar"})
+	c.Assert(err, IsNil)
+	c.Assert(z, Equals, "*new(foo.Bar)")
+
+	z, err = zero("[]int")
+	c.Assert(err, IsNil)
+	c.Assert(z, Equals, "*new([]int)")
+
+	_, err = zero(1)
+	c.Assert(err, ErrorMatches, "zero expects a TypeDef or a type, got int")
+}
+
+func (s *TplSuite) TestQualified(c *C) {
+	g := &Generator{RawType: "github.com/foo/bar as baz:baz.Item", Reduce: []string{"os:os.FileMode"}}
+	c.Assert(g.parseTypes(), IsNil)
+
+	c.Assert(g.qualified("", "Item"), Equals, "Item")
+	c.Assert(g.qualified("github.com/foo/bar", "New"), Equals, "baz.New")
+	c.Assert(g.qualified("os", "Stat"), Equals, "os.Stat")
+	c.Assert(g.qualified("errors", "New"), Equals, "errors.New")
+	c.Assert(g.qualified("encoding/json", "Marshal"), Equals, "json.Marshal")
+	c.Assert(g.qualified("github.com/foo/go-bar", "New"), Equals, "bar.New")
+	c.Assert(g.qualified("github.com/foo/bar/v2", "New"), Equals, "bar.New")
+	c.Assert(g.qualified("gopkg.in/yaml.v2", "Marshal"), Equals, "yaml.Marshal")
+	c.Assert(g.qualified("github.com/foo/baz", "New"), Equals, "baz2.New")
+
+	qualified := g.funcs()["qualified"].(func(string, string) string)
+	qualified("github.com/foo/go-bar", "New")
+	qualified("sort", "Strings")
+	c.Assert(g.requested, DeepEquals, []Import{
+		{Path: "github.com/foo/go-bar", Alias: "bar"},
+		{Path: "sort"},
+	})
+}
+
+func (s *TplSuite) TestGenerateWithTypeInfo(c *C) {
+	dir := c.MkDir()
+	text := `---
+params: key
+---
+{{$t := .}}
+// {{lowerFirst .Name}} {{plural .Name}} {{zero .}} {{zero .Params.key}} {{.IsNumeric}} {{.Params.key.IsString}}
+{{range .Fields}}// {{.Name}} {{.Type}}
+{{end}}`
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "describe.tgo"), []byte(text), 0644), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "foo.go"), []byte("package foo\n\ntype Point struct {\n\tX, Y float64\n}\n"), 0644), IsNil)
+
+	g := New(Options{
+		Type:      "Point",
+		Dir:       dir,
+		Templates: dir,
+		Plugins:   map[string]map[string]string{"describe": {"key": "string"}},
+	})
+	code, _, err := g.GenerateSource()
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Matches, "(?s).*// point Points Point{} \"\" false true\n// X float64\n// Y float64\n.*")
+}
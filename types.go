@@ -0,0 +1,402 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+)
+
+// TypeKind is the kind of type expression a TypeDef was parsed from
+type TypeKind int
+
+const (
+	// NamedKind is a predeclared or named type, like int64 or os.File
+	NamedKind TypeKind = iota
+	// PointerKind is a pointer type, like *os.File
+	PointerKind
+	// SliceKind is a slice type, like []string
+	SliceKind
+	// ArrayKind is an array type, like [4]byte
+	ArrayKind
+	// MapKind is a map type, like map[string]int
+	MapKind
+	// ChanKind is a channel type, like chan int
+	ChanKind
+	// FuncKind is a function type, like func(int) error
+	FuncKind
+	// StructKind is a struct type, like struct{ X int }
+	StructKind
+	// InterfaceKind is an interface type, like interface{}
+	InterfaceKind
+)
+
+// TypeDef is a type definition, with name, package and type
+type TypeDef struct {
+	Name    string
+	Package string
+	Type    string
+	IsChan  bool
+	// IsRecvOnly reports whether the chan type is receive-only, i.e. <-chan T
+	IsRecvOnly bool
+	// Imports are all the imports the type needs, Package being the path
+	// of the first of them. There may be more than one for instantiated
+	// generic types whose type arguments come from other packages.
+	Imports []Import
+	// Kind is the kind of Type, that is, the element type for chan types
+	Kind TypeKind
+	// Qualifiers are the package names Type refers to, in order of appearance
+	Qualifiers []string
+
+	// IsPointer, IsComparable, IsNumeric and IsString describe Type, as
+	// reported by go/types once the type is resolved. They are all false if
+	// it could not be type checked.
+	IsPointer    bool
+	IsComparable bool
+	IsNumeric    bool
+	IsString     bool
+	// Fields are the fields of the underlying struct of Type, or of the
+	// struct it points to.
+	Fields []Field
+
+	// zero is the zero value of Type, empty if it could not be type checked.
+	zero string
+	// fieldImports are the packages the types of Fields refer to.
+	fieldImports []Import
+}
+
+// Field is a field of a struct type
+type Field struct {
+	Name string
+	// Type is the type of the field, qualified by the names of the packages
+	// in the generated code.
+	Type     string
+	Embedded bool
+}
+
+// Import is an import path with an optional alias
+type Import struct {
+	Path  string
+	Alias string
+}
+
+// typeSpecHint is the suggestion given along with invalid type specs.
+const typeSpecHint = `expecting a Go type optionally prefixed by its import paths, like "[]int" or "path/to/pkg:pkg.Type"`
+
+// parseTypeDef parses a type spec in the form "[import/path [as alias],...:]type",
+// where type is any valid Go type expression, including instantiated generic
+// types.
+func parseTypeDef(raw string) (TypeDef, error) {
+	var t TypeDef
+
+	imports, typ, err := splitTypeSpec(raw)
+	if err != nil {
+		return t, err
+	}
+
+	fset := token.NewFileSet()
+	expr, err := parser.ParseExprFrom(fset, "", typ, 0)
+	if err != nil {
+		return t, fmt.Errorf("invalid type given: %s: %s, %s", typ, err, typeSpecHint)
+	}
+
+	if ch, ok := expr.(*ast.ChanType); ok {
+		if ch.Dir == ast.SEND {
+			return t, fmt.Errorf("invalid channel type given: %s: send-only channels can not be iterated, use chan or <-chan instead", typ)
+		}
+
+		t.IsChan = true
+		t.IsRecvOnly = ch.Dir == ast.RECV
+		expr = ch.Value
+	}
+
+	t.Name, err = typeName(expr)
+	if err != nil {
+		return t, fmt.Errorf("invalid type given: %s: %s, %s", typ, err, typeSpecHint)
+	}
+
+	if len(imports) > 0 {
+		t.Package = imports[0].Path
+	}
+	t.Imports = imports
+	t.Type = typ[fset.Position(expr.Pos()).Offset:fset.Position(expr.End()).Offset]
+	t.Kind = typeKind(expr)
+	t.Qualifiers = typeQualifiers(expr)
+	return t, nil
+}
+
+// splitTypeSpec splits a raw type spec into its comma-separated imports and
+// its type expression. Only the first colon not preceded by anything that can
+// only appear in a type expression separates the two, so struct tags
+// containing colons are left untouched.
+func splitTypeSpec(raw string) ([]Import, string, error) {
+	idx := strings.Index(raw, ":")
+	if idx < 0 || strings.ContainsAny(raw[:idx], "[]{}()*\"`") {
+		return nil, strings.TrimSpace(raw), nil
+	}
+
+	var imports []Import
+	for _, spec := range strings.Split(raw[:idx], ",") {
+		parts := strings.Fields(spec)
+		switch {
+		case len(parts) == 0:
+			continue
+		case len(parts) == 1:
+			imports = append(imports, Import{Path: parts[0]})
+		case len(parts) == 3 && parts[1] == "as" && token.IsIdentifier(parts[2]):
+			imports = append(imports, Import{Path: parts[0], Alias: parts[2]})
+		default:
+			return nil, "", fmt.Errorf("invalid import given: %s, expecting \"path\" or \"path as alias\"", strings.TrimSpace(spec))
+		}
+	}
+
+	return imports, strings.TrimSpace(raw[idx+1:]), nil
+}
+
+var targetNameRegex = regexp.MustCompile(`^\s*([\pL_][\pL\pN_]*)\s*=`)
+
+// splitTargetName splits the name given to a map or reduce target in the
+// form "Name=type" from its type spec. If there is no name, an empty name is
+// returned along with the unmodified spec.
+func splitTargetName(raw string) (string, string) {
+	m := targetNameRegex.FindStringSubmatch(raw)
+	if m == nil {
+		return "", raw
+	}
+
+	return m[1], raw[len(m[0]):]
+}
+
+func typeKind(expr ast.Expr) TypeKind {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return typeKind(e.X)
+	case *ast.StarExpr:
+		return PointerKind
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return SliceKind
+		}
+		return ArrayKind
+	case *ast.MapType:
+		return MapKind
+	case *ast.ChanType:
+		return ChanKind
+	case *ast.FuncType:
+		return FuncKind
+	case *ast.StructType:
+		return StructKind
+	case *ast.InterfaceType:
+		return InterfaceKind
+	default:
+		return NamedKind
+	}
+}
+
+func typeQualifiers(expr ast.Expr) []string {
+	var (
+		qualifiers []string
+		seen       = make(map[string]bool)
+	)
+
+	ast.Inspect(expr, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		if id, ok := sel.X.(*ast.Ident); ok && !seen[id.Name] {
+			seen[id.Name] = true
+			qualifiers = append(qualifiers, id.Name)
+		}
+		return false
+	})
+
+	return qualifiers
+}
+
+// typeName returns a valid exported identifier for the given type
+// expression. Pointers are dereferenced, so *os.File is named OsFile, and
+// composite types are named after their parts, e.g. map[string]int is named
+// MapStringInt and func(int) error is named FuncIntToError. Instantiated
+// generic types are named after the generic type, without its package, and
+// their type arguments, so pkg.Pair[string, int] is named PairStringInt.
+func typeName(expr ast.Expr) (string, error) {
+	switch e := expr.(type) {
+	case *ast.IndexExpr:
+		return genericTypeName(e.X, []ast.Expr{e.Index})
+	case *ast.IndexListExpr:
+		return genericTypeName(e.X, e.Indices)
+	case *ast.Ident:
+		return strings.Title(e.Name), nil
+	case *ast.SelectorExpr:
+		pkg, ok := e.X.(*ast.Ident)
+		if !ok {
+			return "", fmt.Errorf("unexpected qualifier %s", types.ExprString(e.X))
+		}
+		return strings.Title(pkg.Name) + strings.Title(e.Sel.Name), nil
+	case *ast.ParenExpr:
+		return typeName(e.X)
+	case *ast.StarExpr:
+		return typeName(e.X)
+	case *ast.ArrayType:
+		elem, err := typeName(e.Elt)
+		if err != nil {
+			return "", err
+		}
+
+		if e.Len == nil {
+			return elem + "Slice", nil
+		}
+
+		length, err := arrayLenName(e.Len)
+		if err != nil {
+			return "", err
+		}
+		return elem + "Array" + length, nil
+	case *ast.MapType:
+		key, err := typeName(e.Key)
+		if err != nil {
+			return "", err
+		}
+
+		value, err := typeName(e.Value)
+		if err != nil {
+			return "", err
+		}
+		return "Map" + key + value, nil
+	case *ast.ChanType:
+		value, err := typeName(e.Value)
+		if err != nil {
+			return "", err
+		}
+
+		switch e.Dir {
+		case ast.RECV:
+			return "RecvChan" + value, nil
+		case ast.SEND:
+			return "SendChan" + value, nil
+		default:
+			return "Chan" + value, nil
+		}
+	case *ast.FuncType:
+		params, err := fieldListName(e.Params, false)
+		if err != nil {
+			return "", err
+		}
+
+		results, err := fieldListName(e.Results, false)
+		if err != nil {
+			return "", err
+		}
+
+		if results != "" {
+			results = "To" + results
+		}
+		return "Func" + params + results, nil
+	case *ast.StructType:
+		fields, err := fieldListName(e.Fields, true)
+		if err != nil {
+			return "", err
+		}
+		return "Struct" + fields, nil
+	case *ast.InterfaceType:
+		var methods []string
+		for _, m := range e.Methods.List {
+			if len(m.Names) == 0 {
+				embedded, err := typeName(m.Type)
+				if err != nil {
+					return "", err
+				}
+				methods = append(methods, embedded)
+			}
+
+			for _, n := range m.Names {
+				methods = append(methods, strings.Title(n.Name))
+			}
+		}
+		return "Interface" + strings.Join(methods, ""), nil
+	case *ast.Ellipsis:
+		elem, err := typeName(e.Elt)
+		if err != nil {
+			return "", err
+		}
+		return "Variadic" + elem, nil
+	default:
+		return "", fmt.Errorf("%s is not a type", types.ExprString(expr))
+	}
+}
+
+func genericTypeName(generic ast.Expr, args []ast.Expr) (string, error) {
+	var name string
+	switch e := generic.(type) {
+	case *ast.Ident:
+		name = strings.Title(e.Name)
+	case *ast.SelectorExpr:
+		name = strings.Title(e.Sel.Name)
+	default:
+		return "", fmt.Errorf("%s is not a generic type", types.ExprString(generic))
+	}
+
+	for _, arg := range args {
+		argName, err := typeName(arg)
+		if err != nil {
+			return "", err
+		}
+		name += argName
+	}
+
+	return name, nil
+}
+
+// fieldListName returns the name for a list of fields. If withNames is true
+// the names of the fields are part of it, which is needed for struct fields
+// but not for function parameters.
+func fieldListName(fields *ast.FieldList, withNames bool) (string, error) {
+	if fields == nil {
+		return "", nil
+	}
+
+	var parts []string
+	for _, f := range fields.List {
+		typ, err := typeName(f.Type)
+		if err != nil {
+			return "", err
+		}
+
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+
+		for i := 0; i < n; i++ {
+			if withNames && i < len(f.Names) {
+				parts = append(parts, strings.Title(f.Names[i].Name))
+			}
+			parts = append(parts, typ)
+		}
+	}
+
+	return strings.Join(parts, ""), nil
+}
+
+func arrayLenName(expr ast.Expr) (string, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.INT {
+			return "", fmt.Errorf("invalid array length %s", e.Value)
+		}
+		return e.Value, nil
+	case *ast.Ident:
+		return strings.Title(e.Name), nil
+	case *ast.SelectorExpr:
+		return typeName(e)
+	case *ast.Ellipsis:
+		return "", fmt.Errorf("array length must be specified")
+	default:
+		return "", fmt.Errorf("unsupported array length %s", types.ExprString(expr))
+	}
+}
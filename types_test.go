@@ -0,0 +1,147 @@
+package generator
+
+import . "gopkg.in/check.v1"
+
+type TypesSuite struct{}
+
+var _ = Suite(&TypesSuite{})
+
+func (s *TypesSuite) TestParseTypeDef(c *C) {
+	tcs := []struct {
+		raw    string
+		typ    string
+		pkg    string
+		name   string
+		kind   TypeKind
+		isChan bool
+	}{
+		{"int64", "int64", "", "Int64", NamedKind, false},
+		{"os:*os.File", "*os.File", "os", "OsFile", PointerKind, false},
+		{"foo:**foo.Bar", "**foo.Bar", "foo", "FooBar", PointerKind, false},
+		{"[]string", "[]string", "", "StringSlice", SliceKind, false},
+		{"[4]byte", "[4]byte", "", "ByteArray4", ArrayKind, false},
+		{"map[string]int", "map[string]int", "", "MapStringInt", MapKind, false},
+		{"func(int) error", "func(int) error", "", "FuncIntToError", FuncKind, false},
+		{"func(...string)", "func(...string)", "", "FuncVariadicString", FuncKind, false},
+		{"struct{X, Y int}", "struct{X, Y int}", "", "StructXIntYInt", StructKind, false},
+		{"interface{}", "interface{}", "", "Interface", InterfaceKind, false},
+		{"interface{ String() string }", "interface{ String() string }", "", "InterfaceString", InterfaceKind, false},
+		{"chan []int", "[]int", "", "IntSlice", SliceKind, true},
+		{"chan   float64", "float64", "", "Float64", NamedKind, true},
+		{"[]<-chan int", "[]<-chan int", "", "RecvChanIntSlice", SliceKind, false},
+		{"time:map[string]time.Duration", "map[string]time.Duration", "time", "MapStringTimeDuration", MapKind, false},
+		{"struct{ X int `json:\"x\"` }", "struct{ X int `json:\"x\"` }", "", "StructXInt", StructKind, false},
+	}
+
+	for _, tc := range tcs {
+		t, err := parseTypeDef(tc.raw)
+		c.Assert(err, IsNil, Commentf(tc.raw))
+		c.Assert(t.Type, Equals, tc.typ, Commentf(tc.raw))
+		c.Assert(t.Package, Equals, tc.pkg, Commentf(tc.raw))
+		c.Assert(t.Name, Equals, tc.name, Commentf(tc.raw))
+		c.Assert(t.Kind, Equals, tc.kind, Commentf(tc.raw))
+		c.Assert(t.IsChan, Equals, tc.isChan, Commentf(tc.raw))
+	}
+}
+
+func (s *TypesSuite) TestParseRecvOnlyTypeDef(c *C) {
+	t, err := parseTypeDef("foo:<-chan foo.Event")
+	c.Assert(err, IsNil)
+	c.Assert(t.Type, Equals, "foo.Event")
+	c.Assert(t.Name, Equals, "FooEvent")
+	c.Assert(t.IsChan, Equals, true)
+	c.Assert(t.IsRecvOnly, Equals, true)
+
+	t, err = parseTypeDef("chan (<-chan int)")
+	c.Assert(err, IsNil)
+	c.Assert(t.Type, Equals, "(<-chan int)")
+	c.Assert(t.IsRecvOnly, Equals, false)
+}
+
+func (s *TypesSuite) TestParseGenericTypeDef(c *C) {
+	tcs := []struct {
+		raw  string
+		typ  string
+		imps []Import
+		name string
+	}{
+		{"github.com/foo/pkg:pkg.Pair[string, int]", "pkg.Pair[string, int]", []Import{{Path: "github.com/foo/pkg"}}, "PairStringInt"},
+		{"pkg:*pkg.Result[T]", "*pkg.Result[T]", []Import{{Path: "pkg"}}, "ResultT"},
+		{
+			"github.com/foo/pkg, github.com/bar/other:pkg.Pair[other.Key, []other.Value]",
+			"pkg.Pair[other.Key, []other.Value]",
+			[]Import{{Path: "github.com/foo/pkg"}, {Path: "github.com/bar/other"}},
+			"PairOtherKeyOtherValueSlice",
+		},
+		{"List[map[string]int]", "List[map[string]int]", nil, "ListMapStringInt"},
+		{"chan pkg.Result[int]", "pkg.Result[int]", nil, "ResultInt"},
+	}
+
+	for _, tc := range tcs {
+		t, err := parseTypeDef(tc.raw)
+		c.Assert(err, IsNil, Commentf(tc.raw))
+		c.Assert(t.Type, Equals, tc.typ, Commentf(tc.raw))
+		c.Assert(t.Imports, DeepEquals, tc.imps, Commentf(tc.raw))
+		c.Assert(t.Name, Equals, tc.name, Commentf(tc.raw))
+	}
+}
+
+func (s *TypesSuite) TestSplitTypeSpec(c *C) {
+	imports, typ, err := splitTypeSpec("github.com/foo/go-bar as bar, os:map[bar.Key]*os.File")
+	c.Assert(err, IsNil)
+	c.Assert(typ, Equals, "map[bar.Key]*os.File")
+	c.Assert(imports, DeepEquals, []Import{
+		{Path: "github.com/foo/go-bar", Alias: "bar"},
+		{Path: "os"},
+	})
+
+	_, _, err = splitTypeSpec("github.com/foo/go-bar bar:bar.X")
+	c.Assert(err, ErrorMatches, `invalid import given: github.com/foo/go-bar bar, expecting "path" or "path as alias"`)
+
+	_, _, err = splitTypeSpec("github.com/foo/go-bar as go-bar:bar.X")
+	c.Assert(err, ErrorMatches, `invalid import given: .*`)
+}
+
+func (s *TypesSuite) TestParseTypeDefErrors(c *C) {
+	tcs := []struct {
+		raw string
+		err string
+	}{
+		{"chan<- int", "invalid channel type given: chan<- int: send-only channels can not be iterated, use chan or <-chan instead"},
+		{"[]", "invalid type given: .*"},
+		{"1 + 2", "invalid type given: 1 \\+ 2: 1 \\+ 2 is not a type, expecting a Go type .*"},
+		{"[...]int", "invalid type given: .*array length must be specified, expecting .*"},
+		{"foo()[int]", "invalid type given: .*foo\\(\\) is not a generic type, expecting .*"},
+	}
+
+	for _, tc := range tcs {
+		_, err := parseTypeDef(tc.raw)
+		c.Assert(err, ErrorMatches, tc.err, Commentf(tc.raw))
+	}
+}
+
+func (s *TypesSuite) TestTypeQualifiers(c *C) {
+	t, err := parseTypeDef("map[foo.Key][]*bar.Value")
+	c.Assert(err, IsNil)
+	c.Assert(t.Qualifiers, DeepEquals, []string{"foo", "bar"})
+}
+
+func (s *TypesSuite) TestSplitTargetName(c *C) {
+	tcs := []struct {
+		raw  string
+		name string
+		spec string
+	}{
+		{"int", "", "int"},
+		{"Count=int", "Count", "int"},
+		{" Ctx = github.com/foo/ctx:ctx.MyCtx", "Ctx", " github.com/foo/ctx:ctx.MyCtx"},
+		{"struct{ X int `a=b` }", "", "struct{ X int `a=b` }"},
+		{"github.com/a=b:b.C", "", "github.com/a=b:b.C"},
+	}
+
+	for _, tc := range tcs {
+		name, spec := splitTargetName(tc.raw)
+		c.Assert(name, Equals, tc.name, Commentf(tc.raw))
+		c.Assert(spec, Equals, tc.spec, Commentf(tc.raw))
+	}
+}
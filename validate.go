@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ValidationError lists all the problems found in the options of the
+// iterables to generate, before generating any of them.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "\n")
+}
+
+// validationError returns a ValidationError with the given problems, or nil
+// if there are none.
+func validationError(problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{problems}
+}
+
+// problemsOf returns the problems of the given error, which are all the ones
+// of a ValidationError or the error itself otherwise.
+func problemsOf(err error) []string {
+	if err == nil {
+		return nil
+	}
+
+	if verr, ok := err.(*ValidationError); ok {
+		return verr.Problems
+	}
+	return []string{err.Error()}
+}
+
+// atGenerateLine prefixes the problems of a ValidationError with the file and
+// line of the go:generate directive running go-itergen, if any, so editors
+// can jump to it. Other errors are returned as they are.
+func atGenerateLine(err error) error {
+	file, line := os.Getenv("GOFILE"), os.Getenv("GOLINE")
+	verr, ok := err.(*ValidationError)
+	if !ok || file == "" || line == "" {
+		return err
+	}
+
+	problems := make([]string, len(verr.Problems))
+	for i, p := range verr.Problems {
+		problems[i] = fmt.Sprintf("%s:%s: %s", file, line, p)
+	}
+
+	return errors.New(strings.Join(problems, "\n"))
+}
+
+// checkOperations returns a problem for every enabled operation that is not
+// supported by the iterable. Types must have been parsed before.
+func (g *Generator) checkOperations() []string {
+	var problems []string
+	for _, op := range operations {
+		if !op.IsBuiltin() || !op.enabled(g) || op.supports(g.Type) {
+			continue
+		}
+
+		if g.Type.IsChan {
+			problems = append(problems, fmt.Sprintf("--%s is not supported by chan types, remove it or use a slice type", op.Name))
+		} else {
+			problems = append(problems, fmt.Sprintf("--%s is only supported by chan types, remove it or use a chan type", op.Name))
+		}
+	}
+
+	return problems
+}
+
+// checkTargets returns a problem for every map and reduce target given more
+// than once.
+func (g *Generator) checkTargets() []string {
+	var problems []string
+	for _, targets := range []struct {
+		flag string
+		raw  []string
+	}{{"map", g.Map}, {"reduce", g.Reduce}} {
+		seen := make(map[string]bool)
+		for _, raw := range targets.raw {
+			key := strings.Join(strings.Fields(raw), " ")
+			if seen[key] {
+				problems = append(problems, fmt.Sprintf("--%s %q is given more than once, remove the repeated one", targets.flag, raw))
+			}
+			seen[key] = true
+		}
+	}
+
+	return problems
+}
+
+// typeOption is a type given in the options, along with the option it was
+// given with.
+type typeOption struct {
+	option string
+	t      TypeDef
+}
+
+// typeOptions returns the parsed types of the options. Types must have been
+// parsed before.
+func (g *Generator) typeOptions() []typeOption {
+	opts := []typeOption{{fmt.Sprintf("-t %q", g.RawType), g.Type}}
+	for i, t := range g.MapResults {
+		opts = append(opts, typeOption{fmt.Sprintf("--map %q", g.Map[i]), t})
+	}
+
+	for i, t := range g.ReduceTypes {
+		opts = append(opts, typeOption{fmt.Sprintf("--reduce %q", g.Reduce[i]), t})
+	}
+
+	for _, op := range g.pluginOps {
+		for _, param := range op.op.Params {
+			if t, ok := op.params[param]; ok {
+				option := fmt.Sprintf("--%s-%s %q", op.op.Name, param, g.Plugins[op.op.Name][param])
+				opts = append(opts, typeOption{option, t})
+			}
+		}
+	}
+
+	return opts
+}
+
+// importUse is the import of a package by the type of an option.
+type importUse struct {
+	path   string
+	option string
+}
+
+// checkImports returns a problem for every package imported by the types of
+// the options of the generator, identified by name if it is not empty, with
+// the same name as a different package, which would not compile. uses maps
+// the names of the packages imported by the code generated in the same file
+// to their first import, and it is updated with the ones of the generator.
+// Types must have been parsed before.
+func checkImports(uses map[string]importUse, g *Generator, name string) []string {
+	var problems []string
+	for _, opt := range g.typeOptions() {
+		option := opt.option
+		if name != "" && option != name {
+			option = name + " " + option
+		}
+
+		for _, imp := range typeImports(opt.t) {
+			prev, ok := uses[imp.name()]
+			if !ok {
+				uses[imp.name()] = importUse{imp.Path, option}
+				continue
+			}
+
+			if prev.path != imp.Path {
+				problems = append(problems, fmt.Sprintf(
+					"%s imports %s as %s, like %s imported by %s, give one of them an alias with \"path as alias:\"",
+					option, imp.Path, imp.name(), prev.path, prev.option,
+				))
+			}
+		}
+	}
+
+	return problems
+}
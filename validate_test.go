@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"os"
+
+	. "gopkg.in/check.v1"
+)
+
+type ValidateSuite struct{}
+
+var _ = Suite(&ValidateSuite{})
+
+func (s *ValidateSuite) TestPrepareProblems(c *C) {
+	tcs := []struct {
+		opts     Options
+		problems []string
+	}{
+		{
+			Options{
+				Type:    "chan int",
+				Package: "foo",
+				Map:     []string{"string", "string", "[]"},
+				Reduce:  []string{"int", "Int=int"},
+				Some:    true,
+				Splice:  true,
+				Array:   true,
+			},
+			[]string{
+				`--map "\[\]": invalid type given: \[\]: .*, expecting a Go type .*`,
+				"--some is not supported by chan types, remove it or use a slice type",
+				"--splice is not supported by chan types, remove it or use a slice type",
+				`--map "string" is given more than once, remove the repeated one`,
+			},
+		},
+		{
+			Options{Type: "int", Package: "foo", Name: "My Ints", Array: true, Find: true},
+			[]string{
+				"invalid iterable name given: My Ints, it must be a valid Go identifier",
+				"--array is only supported by chan types, remove it or use a chan type",
+			},
+		},
+		{
+			Options{Type: "int", Package: "foo", Reduce: []string{"int", "Int=int", "string", "Int=string"}},
+			[]string{
+				`method IntIter.ReduceInt generated for --reduce "Int=int" collides with the one generated for --reduce "int", .*`,
+				`method IntIter.ReduceInt generated for --reduce "Int=string" collides with the one generated for --reduce "int", .*`,
+			},
+		},
+		{
+			Options{Type: "nope.Int", Package: "foo", Map: []string{"nope.String"}, Filter: true},
+			[]string{
+				`-t "nope.Int": cannot find package nope, .*`,
+				`--map "nope.String": cannot find package nope, .*`,
+			},
+		},
+		{
+			Options{
+				Type:    "text/template:template.Template",
+				Package: "foo",
+				Map:     []string{"H=html/template:template.Template", "html/template as htpl:htpl.JS"},
+				Reduce:  []string{"Tpl=text/template:*template.Template"},
+			},
+			[]string{
+				`--map "H=html/template:template.Template" imports html/template as template, like text/template imported by -t "text/template:template.Template", give one of them an alias with "path as alias:"`,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		err := New(tc.opts).prepare()
+		c.Assert(err, FitsTypeOf, &ValidationError{})
+
+		problems := err.(*ValidationError).Problems
+		c.Assert(problems, HasLen, len(tc.problems), Commentf("%v", problems))
+		for i, p := range problems {
+			c.Assert(p, Matches, tc.problems[i])
+		}
+	}
+}
+
+func (s *ValidateSuite) TestAtGenerateLine(c *C) {
+	defer os.Unsetenv("GOFILE")
+	defer os.Unsetenv("GOLINE")
+
+	err := &ValidationError{[]string{"--some is not supported", "--splice is not supported"}}
+	c.Assert(atGenerateLine(err), Equals, err)
+
+	os.Setenv("GOFILE", "foo.go")
+	os.Setenv("GOLINE", "12")
+	c.Assert(atGenerateLine(err), ErrorMatches, `foo.go:12: --some is not supported
+foo.go:12: --splice is not supported`)
+	c.Assert(atGenerateLine(os.ErrNotExist), Equals, os.ErrNotExist)
+	c.Assert(atGenerateLine(nil), IsNil)
+
+	dir := c.MkDir()
+	g := New(Options{Type: "chan int", Package: "foo", Some: true, Dir: dir})
+	c.Assert(g.Generate(), ErrorMatches, "foo.go:12: --some is not supported by chan types, .*")
+}
@@ -0,0 +1,337 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultWatchInterval is the interval a Watcher polls the sources at when it
+// has none.
+const DefaultWatchInterval = time.Second
+
+// Watcher regenerates the iterables of packages when the files they are
+// generated from change. Files are polled, so it works on any file system.
+type Watcher struct {
+	// Patterns are the patterns of the packages to watch, as given to
+	// GeneratePackages.
+	Patterns []string
+	// Interval is the time between polls, DefaultWatchInterval if zero.
+	Interval time.Duration
+	// Force makes the watcher overwrite files not generated by go-itergen.
+	Force bool
+	// Report is called with the result of every package that is
+	// regenerated, and with the errors finding the packages, which have no
+	// Dir.
+	Report func(PackageResult)
+
+	// packages are the states of the packages when they were last
+	// generated, by directory.
+	packages map[string]*watchedPackage
+}
+
+// watchedPackage is the state of a package when its iterables were last
+// generated.
+type watchedPackage struct {
+	// sources are the states of the files the iterables are generated from.
+	sources map[string]fileState
+	// pkg is the package the code is generated in when none is given.
+	pkg string
+	// iterables are the keys of the iterables generated without errors,
+	// see iterableKey.
+	iterables map[string]bool
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watch generates the iterables of all the packages and regenerates them
+// every time their sources change, until stop is closed. Errors are reported
+// and never stop the watcher.
+func (w *Watcher) Watch(stop <-chan struct{}) {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		w.poll()
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll regenerates the iterables of the packages affected by the changes in
+// their sources since the last poll, see affected.
+func (w *Watcher) poll() {
+	if w.packages == nil {
+		w.packages = make(map[string]*watchedPackage)
+	}
+
+	dirs, err := matchPackages(w.Patterns)
+	if err != nil {
+		w.report(PackageResult{Err: err})
+		return
+	}
+
+	matched := make(map[string]bool)
+	for _, dir := range dirs {
+		matched[dir] = true
+		sources, err := packageSources(dir)
+		if err != nil {
+			w.report(PackageResult{Dir: dir, Err: err})
+			continue
+		}
+
+		prev, ok := w.packages[dir]
+		if ok && sameSources(prev.sources, sources) {
+			continue
+		}
+
+		pkg, _ := inferPackage(dir, false)
+		state := &watchedPackage{sources: sources, pkg: pkg, iterables: make(map[string]bool)}
+		w.packages[dir] = state
+
+		var (
+			changed     = changedSources(prev, sources)
+			regenerated []string
+		)
+
+		result := generatePackage(dir, w.Force, false, func(g *Generator) bool {
+			key := iterableKey(g)
+			state.iterables[key] = true
+			if !ok || prev.pkg != pkg || !prev.iterables[key] || affected(g, changed) {
+				regenerated = append(regenerated, key)
+				return true
+			}
+			return false
+		})
+
+		// the iterables that failed are regenerated on the next change,
+		// as if they were new
+		if result.Err != nil {
+			for _, key := range regenerated {
+				delete(state.iterables, key)
+			}
+		}
+
+		w.report(result)
+	}
+
+	// forget the packages no longer matched, so they are regenerated if
+	// they are matched again
+	for dir := range w.packages {
+		if !matched[dir] {
+			delete(w.packages, dir)
+		}
+	}
+}
+
+// iterableKey returns the key identifying an iterable of a package across
+// polls, which is the command generating it, so an iterable whose options
+// change is a different one.
+func iterableKey(g *Generator) string {
+	return g.origin + "\n" + g.commandFor([]string{g.RawType})
+}
+
+// changedSources returns the files that are new, removed or modified in the
+// given sources since the given state of the package, which may be nil.
+func changedSources(prev *watchedPackage, sources map[string]fileState) []string {
+	var changed []string
+	if prev == nil {
+		return changed
+	}
+
+	for file, state := range sources {
+		old, ok := prev.sources[file]
+		if !ok || !state.modTime.Equal(old.modTime) || state.size != old.size {
+			changed = append(changed, file)
+		}
+	}
+
+	for file := range prev.sources {
+		if _, ok := sources[file]; !ok {
+			changed = append(changed, file)
+		}
+	}
+
+	return changed
+}
+
+// affected reports whether the code of the iterable may change with the
+// given changed files, which is when they are templates in its templates
+// directory or Go files of the package and its types refer to the types
+// declared in the package. Changes in the options of the iterable make it a
+// different one, see iterableKey.
+func affected(g *Generator, changed []string) bool {
+	for _, file := range changed {
+		switch filepath.Ext(file) {
+		case ".tgo":
+			if g.Templates != "" && filepath.Dir(file) == filepath.Clean(g.Templates) {
+				return true
+			}
+		case ".go":
+			if usesPackageTypes(g) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// usesPackageTypes reports whether any type of the iterable refers to a type
+// declared in its package, which is any identifier that is neither
+// qualified nor predeclared.
+func usesPackageTypes(g *Generator) bool {
+	if err := g.parseTypes(); err != nil {
+		return true
+	}
+
+	defs := g.typeDefs()
+	for _, params := range g.Plugins {
+		for _, raw := range params {
+			t, err := g.parseType(raw)
+			if err != nil {
+				return true
+			}
+			defs = append(defs, t)
+		}
+	}
+
+	for _, t := range defs {
+		expr, err := parser.ParseExpr(t.Type)
+		if err != nil || refersToLocalTypes(expr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func refersToLocalTypes(node ast.Node) bool {
+	var local bool
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			// qualified identifiers are declared in other packages
+			return false
+		case *ast.Field:
+			// the names of fields and parameters are not types
+			local = local || refersToLocalTypes(n.Type)
+			return false
+		case *ast.Ident:
+			if types.Universe.Lookup(n.Name) == nil {
+				local = true
+			}
+		}
+		return !local
+	})
+	return local
+}
+
+func (w *Watcher) report(result PackageResult) {
+	if w.Report != nil {
+		w.Report(result)
+	}
+}
+
+// packageSources returns the state of the files the iterables of the
+// package in dir are generated from, which are its config file, all its Go
+// files but the generated by go-itergen and the templates in the templates
+// directories of its iterables.
+func packageSources(dir string) (map[string]fileState, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string]fileState)
+	for _, tplDir := range templateDirs(dir) {
+		tplFiles, err := ioutil.ReadDir(tplDir)
+		if err != nil {
+			// the error is reported when the package is generated
+			continue
+		}
+
+		for _, f := range tplFiles {
+			if !f.IsDir() && filepath.Ext(f.Name()) == ".tgo" {
+				sources[filepath.Join(tplDir, f.Name())] = fileState{f.ModTime(), f.Size()}
+			}
+		}
+	}
+
+	for _, f := range files {
+		file := filepath.Join(dir, f.Name())
+		if f.IsDir() || (f.Name() != DefaultConfigFile && filepath.Ext(file) != ".go") {
+			continue
+		}
+
+		if strings.HasSuffix(file, ".go") {
+			generated, err := isGenerated(file)
+			if err != nil {
+				return nil, err
+			}
+
+			if generated {
+				continue
+			}
+		}
+
+		sources[file] = fileState{f.ModTime(), f.Size()}
+	}
+
+	return sources, nil
+}
+
+// templateDirs returns the templates directories used by the iterables of
+// the config and the annotated types of the package in dir, ignoring the
+// ones that can not be loaded.
+func templateDirs(dir string) []string {
+	var gens []*Generator
+	file := filepath.Join(dir, DefaultConfigFile)
+	if config, err := LoadConfig(file); err == nil {
+		gens, _ = configGenerators(file, config, false, false)
+	}
+
+	if annotated, _, err := annotationGenerators(dir, false, false); err == nil {
+		gens = append(gens, annotated...)
+	}
+
+	var dirs []string
+	for _, g := range gens {
+		if g.Templates != "" && !containsString(dirs, g.Templates) {
+			dirs = append(dirs, g.Templates)
+		}
+	}
+
+	return dirs
+}
+
+func sameSources(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for file, state := range a {
+		other, ok := b[file]
+		if !ok || !state.modTime.Equal(other.modTime) || state.size != other.size {
+			return false
+		}
+	}
+
+	return true
+}
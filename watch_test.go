@@ -0,0 +1,195 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type WatchSuite struct {
+	dir     string
+	writes  int
+	results []PackageResult
+}
+
+var _ = Suite(&WatchSuite{})
+
+func (s *WatchSuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+	s.writes = 0
+	s.results = nil
+}
+
+func (s *WatchSuite) write(c *C, file, code string) {
+	file = filepath.Join(s.dir, file)
+	c.Assert(os.MkdirAll(filepath.Dir(file), 0755), IsNil)
+	c.Assert(ioutil.WriteFile(file, []byte(code), 0644), IsNil)
+
+	// make sure the change is noticed even on file systems with a coarse
+	// modification time
+	s.writes++
+	future := time.Now().Add(time.Duration(s.writes) * time.Second)
+	c.Assert(os.Chtimes(file, future, future), IsNil)
+}
+
+func (s *WatchSuite) report(r PackageResult) {
+	s.results = append(s.results, r)
+}
+
+func (s *WatchSuite) dirs() []string {
+	var dirs []string
+	for _, r := range s.results {
+		dirs = append(dirs, r.Dir)
+	}
+	s.results = nil
+	return dirs
+}
+
+func (s *WatchSuite) TestPoll(c *C) {
+	s.write(c, "ann/ann.go", "package ann\n\n//itergen:ops filter\ntype Order struct{}\n")
+	s.write(c, "cfg/cfg.go", "package cfg\n")
+	s.write(c, "cfg/itergen.json", `{"iterables": [{"type": "int", "filter": true}]}`)
+
+	w := &Watcher{Patterns: []string{s.dir + "/..."}, Report: s.report}
+	ann, cfg := filepath.Join(s.dir, "ann"), filepath.Join(s.dir, "cfg")
+
+	w.poll()
+	c.Assert(s.dirs(), DeepEquals, []string{ann, cfg})
+
+	// generated files do not trigger a regeneration
+	w.poll()
+	c.Assert(s.dirs(), HasLen, 0)
+
+	s.write(c, "cfg/itergen.json", `{"iterables": [{"type": "int", "filter": true, "some": true}]}`)
+	w.poll()
+	c.Assert(s.dirs(), DeepEquals, []string{cfg})
+
+	s.write(c, "ann/ann.go", "package ann\n\n//itergen:ops chan,some\ntype Order struct{}\n")
+	w.poll()
+	c.Assert(s.results, HasLen, 1)
+	c.Assert(s.results[0].Err, ErrorMatches, ".*--some is not supported by chan types, .*")
+	s.results = nil
+
+	// errors are not retried until the sources change again
+	w.poll()
+	c.Assert(s.dirs(), HasLen, 0)
+
+	s.write(c, "ann/ann.go", "package ann\n\n//itergen:ops chan,filter\ntype Order struct{}\n")
+	w.poll()
+	c.Assert(s.results, HasLen, 1)
+	c.Assert(s.results[0].Err, IsNil)
+	c.Assert(s.results[0].Files, DeepEquals, []string{filepath.Join(ann, "orderchan_iter.go")})
+}
+
+func (s *WatchSuite) TestPollTemplates(c *C) {
+	s.write(c, "cfg.go", "package cfg\n")
+	s.write(c, "itergen.json", `{"templates": "tpl", "iterables": [{"type": "int", "filter": true}]}`)
+	s.write(c, "tpl/type.tgo", "type {{.Name}}Iter []{{.Type}}\n")
+
+	w := &Watcher{Patterns: []string{s.dir}, Report: s.report}
+	w.poll()
+	c.Assert(s.dirs(), DeepEquals, []string{s.dir})
+
+	s.write(c, "tpl/type.tgo", "type {{.Name}}Iter []{{.Type}}\n\n// custom\n")
+	w.poll()
+	c.Assert(s.results, HasLen, 1)
+	c.Assert(s.results[0].Err, IsNil)
+	s.results = nil
+
+	code, err := ioutil.ReadFile(filepath.Join(s.dir, "int_iter.go"))
+	c.Assert(err, IsNil)
+	c.Assert(string(code), Matches, "(?s).*// custom\n.*")
+
+	// templates not used by the config are not watched
+	s.write(c, "other/type.tgo", "// other\n")
+	w.poll()
+	c.Assert(s.dirs(), HasLen, 0)
+}
+
+func (s *WatchSuite) TestPollAffected(c *C) {
+	s.write(c, "foo.go", "package foo\n\ntype Local struct{}\n\n//itergen:ops filter\ntype Order struct{}\n")
+	s.write(c, "tpl/type.tgo", "type {{.Name}}Iter []{{.Type}}\n")
+	s.write(c, "itergen.json", `{"iterables": [
+		{"type": "int", "filter": true},
+		{"type": "Local", "filter": true},
+		{"type": "string", "filter": true, "templates": "tpl"}
+	]}`)
+
+	w := &Watcher{Patterns: []string{s.dir}, Report: s.report}
+	w.poll()
+	c.Assert(s.files(c), DeepEquals, []string{"int_iter.go", "local_iter.go", "string_iter.go", "order_iter.go"})
+
+	// only the iterables of the types of the package are affected by
+	// changes in its Go files
+	s.write(c, "foo.go", "package foo\n\ntype Local struct{ X int }\n\n//itergen:ops filter\ntype Order struct{}\n")
+	w.poll()
+	c.Assert(s.files(c), DeepEquals, []string{"local_iter.go", "order_iter.go"})
+
+	s.write(c, "tpl/type.tgo", "type {{.Name}}Iter []{{.Type}}\n\n// custom\n")
+	w.poll()
+	c.Assert(s.files(c), DeepEquals, []string{"string_iter.go"})
+
+	s.write(c, "itergen.json", `{"iterables": [
+		{"type": "int", "filter": true, "some": true},
+		{"type": "string", "filter": true, "templates": "tpl"}
+	]}`)
+	w.poll()
+	c.Assert(s.files(c), DeepEquals, []string{"int_iter.go"})
+	_, err := os.Stat(filepath.Join(s.dir, "local_iter.go"))
+	c.Assert(os.IsNotExist(err), Equals, true)
+
+	s.write(c, "foo.go", "package foo\n\n//itergen:ops filter,reverse\ntype Order struct{}\n")
+	w.poll()
+	c.Assert(s.files(c), DeepEquals, []string{"order_iter.go"})
+
+	// the iterables that fail are regenerated with any change
+	s.write(c, "itergen.json", `{"iterables": [
+		{"type": "int", "filter": true, "some": true},
+		{"type": "string", "filter": true, "templates": "tpl"},
+		{"type": "Nope", "filter": true}
+	]}`)
+	w.poll()
+	c.Assert(s.results, HasLen, 1)
+	c.Assert(s.results[0].Err, NotNil)
+	s.results = nil
+
+	s.write(c, "foo.go", "package foo\n\ntype Nope int\n\n//itergen:ops filter,reverse\ntype Order struct{}\n")
+	w.poll()
+	c.Assert(s.files(c), DeepEquals, []string{"nope_iter.go", "order_iter.go"})
+}
+
+// files returns the base names of the files of the only result reported,
+// which must have no error.
+func (s *WatchSuite) files(c *C) []string {
+	c.Assert(s.results, HasLen, 1)
+	c.Assert(s.results[0].Err, IsNil)
+
+	var files []string
+	for _, f := range s.results[0].Files {
+		files = append(files, filepath.Base(f))
+	}
+	s.results = nil
+	return files
+}
+
+func (s *WatchSuite) TestWatch(c *C) {
+	s.write(c, "ann.go", "package ann\n\n//itergen:ops filter\ntype Order struct{}\n")
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	w := &Watcher{Patterns: []string{s.dir}, Interval: time.Millisecond, Report: s.report}
+	go func() {
+		w.Watch(stop)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(stop)
+	<-done
+
+	c.Assert(s.results, HasLen, 1)
+	c.Assert(s.results[0].Err, IsNil)
+}